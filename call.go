@@ -0,0 +1,162 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Call is a record of a single call to a mock method handled by a controller.
+// See Controller.Calls.
+type Call struct {
+	// The mock object on which the method was called, and its description at
+	// the time of the call.
+	Object      MockObject
+	Description string
+
+	// The name of the method called, and the arguments it was called with. As
+	// with HandleMethodCall, the arguments for a variadic parameter are
+	// represented by a single slice.
+	MethodName string
+	Args       []interface{}
+
+	// The values returned to the caller, recorded once the call returns.
+	Returns []interface{}
+
+	// The location of the call site, if known.
+	FileName   string
+	LineNumber int
+
+	// The ID of the goroutine that made the call, and the time at which it was
+	// made.
+	Goroutine int64
+	Time      time.Time
+
+	// The expectation that the call was counted against, or nil if it didn't
+	// match any expectation.
+	Expectation *InternalExpectation
+}
+
+// Format a list of values for inclusion in a call transcript.
+func formatValues(vals []interface{}) string {
+	strs := make([]string, len(vals))
+	for i, v := range vals {
+		strs[i] = fmt.Sprintf("%#v", v)
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// String returns a one-line description of the call, suitable for inclusion
+// in a transcript.
+func (c Call) String() string {
+	s := fmt.Sprintf(
+		"%s:%d: %s(%s) on %q returned (%s)",
+		path.Base(c.FileName),
+		c.LineNumber,
+		c.MethodName,
+		formatValues(c.Args),
+		c.Description,
+		formatValues(c.Returns))
+
+	if c.Expectation == nil {
+		s += " [unexpected]"
+	} else {
+		s += fmt.Sprintf(
+			" [expectation at %s:%d]",
+			path.Base(c.Expectation.FileName),
+			c.Expectation.LineNumber)
+	}
+
+	return s
+}
+
+// CallList is an ordered list of calls, as returned by Controller.Calls.
+type CallList []Call
+
+// ForObject returns the calls in the list made on the supplied mock object.
+func (l CallList) ForObject(o MockObject) (filtered CallList) {
+	for _, c := range l {
+		if c.Object.Oglemock_Id() == o.Oglemock_Id() {
+			filtered = append(filtered, c)
+		}
+	}
+
+	return
+}
+
+// ForMethod returns the calls in the list made to the method with the supplied
+// name.
+func (l CallList) ForMethod(methodName string) (filtered CallList) {
+	for _, c := range l {
+		if c.MethodName == methodName {
+			filtered = append(filtered, c)
+		}
+	}
+
+	return
+}
+
+// Unexpected returns the calls in the list that didn't match any expectation.
+func (l CallList) Unexpected() (filtered CallList) {
+	for _, c := range l {
+		if c.Expectation == nil {
+			filtered = append(filtered, c)
+		}
+	}
+
+	return
+}
+
+// String returns a transcript of the calls in the list, one per line.
+func (l CallList) String() string {
+	buf := new(bytes.Buffer)
+	for i, c := range l {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		buf.WriteString(c.String())
+	}
+
+	return buf.String()
+}
+
+// Return the ID of the current goroutine, or zero if it can't be determined.
+// The runtime doesn't export this, so it is parsed out of the first line of
+// the goroutine's stack trace, which looks like "goroutine 17 [running]:".
+func currentGoroutine() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+
+	fields := strings.Fields(string(buf))
+	if len(fields) < 2 || fields[0] != "goroutine" {
+		return 0
+	}
+
+	id, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
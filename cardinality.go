@@ -0,0 +1,82 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock
+
+import (
+	"fmt"
+	"math"
+)
+
+// Cardinality describes the range of the number of times an expectation may
+// be matched. See Expectation.Cardinality.
+type Cardinality struct {
+	min uint
+	max uint // math.MaxUint32 means unbounded
+}
+
+// Exactly returns a cardinality that allows exactly n calls.
+func Exactly(n uint) Cardinality {
+	return Cardinality{n, n}
+}
+
+// AtLeast returns a cardinality that allows n or more calls.
+func AtLeast(n uint) Cardinality {
+	return Cardinality{n, math.MaxUint32}
+}
+
+// AtMost returns a cardinality that allows at most n calls, including zero.
+func AtMost(n uint) Cardinality {
+	return Cardinality{0, n}
+}
+
+// Between returns a cardinality that allows at least min and at most max
+// calls. min must not be greater than max.
+func Between(min, max uint) Cardinality {
+	return Cardinality{min, max}
+}
+
+// AnyNumber returns a cardinality that allows any number of calls, including
+// zero.
+func AnyNumber() Cardinality {
+	return Cardinality{0, math.MaxUint32}
+}
+
+func pluralizeTimes(n uint) string {
+	if n == 1 {
+		return "1 time"
+	}
+
+	return fmt.Sprintf("%d times", n)
+}
+
+// String returns a description of the cardinality, such as "at least 2 times".
+func (c Cardinality) String() string {
+	switch {
+	case c.min == c.max:
+		return "exactly " + pluralizeTimes(c.min)
+
+	case c.max == math.MaxUint32 && c.min == 0:
+		return "any number of times"
+
+	case c.max == math.MaxUint32:
+		return "at least " + pluralizeTimes(c.min)
+
+	case c.min == 0:
+		return "at most " + pluralizeTimes(c.max)
+	}
+
+	return fmt.Sprintf("between %d and %s", c.min, pluralizeTimes(c.max))
+}
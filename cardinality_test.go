@@ -0,0 +1,67 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock_test
+
+import (
+	"testing"
+
+	"github.com/jacobsa/oglemock"
+	. "github.com/jacobsa/ogletest"
+)
+
+func TestCardinality(t *testing.T) { RunTests(t) }
+
+////////////////////////////////////////////////////////////
+// Boilerplate
+////////////////////////////////////////////////////////////
+
+type CardinalityTest struct {
+}
+
+func init() { RegisterTestSuite(&CardinalityTest{}) }
+
+////////////////////////////////////////////////////////////
+// Test functions
+////////////////////////////////////////////////////////////
+
+func (t *CardinalityTest) Exactly() {
+	ExpectEq("exactly 0 times", oglemock.Exactly(0).String())
+	ExpectEq("exactly 1 time", oglemock.Exactly(1).String())
+	ExpectEq("exactly 3 times", oglemock.Exactly(3).String())
+	ExpectEq("exactly 2 times", oglemock.Between(2, 2).String())
+}
+
+func (t *CardinalityTest) AtLeast() {
+	ExpectEq("any number of times", oglemock.AtLeast(0).String())
+	ExpectEq("at least 1 time", oglemock.AtLeast(1).String())
+	ExpectEq("at least 3 times", oglemock.AtLeast(3).String())
+}
+
+func (t *CardinalityTest) AtMost() {
+	ExpectEq("exactly 0 times", oglemock.AtMost(0).String())
+	ExpectEq("at most 1 time", oglemock.AtMost(1).String())
+	ExpectEq("at most 3 times", oglemock.AtMost(3).String())
+}
+
+func (t *CardinalityTest) Between() {
+	ExpectEq("at most 1 time", oglemock.Between(0, 1).String())
+	ExpectEq("between 1 and 2 times", oglemock.Between(1, 2).String())
+	ExpectEq("between 2 and 5 times", oglemock.Between(2, 5).String())
+}
+
+func (t *CardinalityTest) AnyNumber() {
+	ExpectEq("any number of times", oglemock.AnyNumber().String())
+}
@@ -16,12 +16,17 @@
 package oglemock
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"math"
 	"reflect"
+	"strings"
 	"sync"
+	"time"
+
+	"github.com/jacobsa/oglematchers"
 )
 
 // PartialExpecation is a function that should be called exactly once with
@@ -51,6 +56,19 @@ type Controller interface {
 	//     controller.ExpectCall(mockWriter, "Write", "foo.go", 17)(ElementsAre(0x1))
 	//         .WillOnce(Return(1, nil))
 	//
+	// For a variadic method, the arguments may end with either a single value
+	// or matcher for the variadic slice as a whole, or one value or matcher per
+	// element of the slice. For example, for a method with signature
+	// Printf(format string, a ...interface{}) the following are equivalent:
+	//
+	//     controller.ExpectCall(o, "Printf", "foo.go", 17)("%d %d", 1, Any())
+	//     controller.ExpectCall(o, "Printf", "foo.go", 17)("%d %d", ElementsAre(1, Any()))
+	//
+	// A lone value in the variadic position is taken to be for the slice as a
+	// whole if it is assignable to the slice type. A lone matcher or nil
+	// matches either the slice as a whole or a slice with a single element, so
+	// that ("%d", Equals(1)) matches Printf("%d", 1).
+	//
 	// If the mock object doesn't have a method of the supplied name, the
 	// function reports a fatal error and returns nil.
 	ExpectCall(
@@ -59,25 +77,131 @@ type Controller interface {
 		fileName string,
 		lineNumber int) PartialExpecation
 
+	// OnCall sets up a default behavior for calls to the method of the given
+	// name on the supplied mock object. It returns a function that should be
+	// called with the arguments or matchers for which the behavior applies, and
+	// whose result should then be given an action with WillByDefault.
+	//
+	// Unlike ExpectCall, OnCall expresses no expectation about whether or how
+	// many times the method is called. The default action is used for matching
+	// calls that don't match any expectation (which may still be reported as
+	// errors, depending on the mock object's mode), and for calls matching an
+	// expectation that has no action of its own to invoke. When more than one
+	// default behavior matches, the one set up most recently is used.
+	//
+	// For example:
+	//
+	//     controller.OnCall(mockBucket, "Name", "foo.go", 17)()
+	//         .WillByDefault(Return("some-bucket"))
+	//
+	// Variadic methods are handled as for ExpectCall.
+	//
+	// If the mock object doesn't have a method of the supplied name, the
+	// function reports a fatal error and returns nil.
+	OnCall(
+		o MockObject,
+		methodName string,
+		fileName string,
+		lineNumber int) PartialOnCall
+
 	// Finish causes the controller to check for any unsatisfied expectations,
-	// and report them as errors if they exist.
+	// and report them as errors if they exist. Calling it again has no further
+	// effect, so it's safe to defer a call to Finish for a controller created
+	// with NewControllerForTest.
 	//
 	// The controller may panic if any of its methods (including this one) are
 	// called after Finish is called.
 	Finish()
 
+	// Checkpoint verifies the expectations registered so far in the same way
+	// that Finish does, reporting any that are unsatisfied, and then discards
+	// them along with the record of calls (see Calls). Unlike Finish, the
+	// controller may continue to be used afterward, so that a test may set up
+	// new expectations for a later phase. Default behaviors registered with
+	// OnCall and mock object modes are preserved.
+	//
+	// Calls to methods whose expectations have been discarded are treated as if
+	// the expectations were never registered. The expectations are removed from
+	// any sequences they belong to, so an expectation added to one of those
+	// sequences later doesn't need to follow them.
+	Checkpoint()
+
+	// VerifyObject is like Finish, but verifies only the expectations
+	// registered for the supplied mock object. The expectations are kept, and
+	// the controller may continue to be used afterward.
+	VerifyObject(o MockObject)
+
+	// ResetObject discards all of the expectations registered for the supplied
+	// mock object without verifying them, along with the record of calls made
+	// on it (see Calls), leaving those for other objects untouched. This is
+	// useful for fixtures that replace a single dependency part way through a
+	// test, e.g. when reconnecting a mock connection; call VerifyObject first
+	// to check the old expectations.
+	ResetObject(o MockObject)
+
+	// WaitForSatisfied blocks until every expectation registered so far has
+	// been matched at least as many times as its cardinality requires, or until
+	// the context is cancelled. It returns nil in the former case and the
+	// context's error in the latter. This is useful when the calls are made by
+	// background goroutines.
+	WaitForSatisfied(ctx context.Context) error
+
+	// FinishWithTimeout is like Finish, but first waits up to the supplied
+	// timeout for all expectations to be satisfied (see WaitForSatisfied).
+	FinishWithTimeout(timeout time.Duration)
+
+	// SetMode sets the mode for the supplied mock object, controlling how calls
+	// to methods for which no expectations have been registered on that object
+	// are treated. See MockMode for details. Objects for which SetMode has not
+	// been called use the default mode (see SetDefaultMode).
+	SetMode(o MockObject, mode MockMode)
+
+	// SetDefaultMode sets the mode used for mock objects for which SetMode has
+	// not been called. The initial default mode is Strict.
+	SetDefaultMode(mode MockMode)
+
+	// Calls returns a record of the calls handled by HandleMethodCall so far,
+	// in the order in which they were handled. Only the most recent calls are
+	// kept (see SetCallHistoryLimit). Calls that were rejected with a fatal
+	// error (e.g. for an unknown method) are not included.
+	//
+	// The result can be narrowed down with CallList's methods, for example:
+	//
+	//     writes := controller.Calls().ForObject(mockFile).ForMethod("Write")
+	//
+	Calls() CallList
+
+	// SetCallHistoryLimit sets the number of calls to keep a record of for
+	// Calls and for the transcripts in error messages, after which the oldest
+	// are discarded. The initial limit is DefaultCallHistoryLimit. A limit of
+	// zero turns recording off, which also saves taking a stack trace per call,
+	// and a negative limit removes it. Calls recorded so far are kept, up to
+	// the new limit.
+	SetCallHistoryLimit(n int)
+
 	// HandleMethodCall looks for a registered expectation matching the call of
 	// the given method on mock object o, invokes the appropriate action (if
 	// any), and returns the values returned by that action (if any).
 	//
 	// If the action returns nothing, the controller returns zero values. If
 	// there is no matching expectation, the controller reports an error and
-	// returns zero values.
+	// returns zero values. The error lists each expectation registered for the
+	// method, closest match first, along with the reason each argument did or
+	// did not match. If no expectations at all have been registered for the
+	// method, the error may instead be downgraded to a warning or suppressed
+	// according to the mock object's mode.
+	//
+	// Wherever zero values would be returned above, the action of a matching
+	// default behavior configured with OnCall is invoked instead, if there is
+	// one.
 	//
 	// If the mock object doesn't have a method of the supplied name, the
 	// arguments are of the wrong type, or the action returns the wrong types,
 	// the function reports a fatal error.
 	//
+	// The arguments for a variadic parameter must be supplied as a single
+	// slice, as in the method's own parameter list.
+	//
 	// HandleMethodCall is exported for the sake of mock implementations, and
 	// should not be used directly.
 	HandleMethodCall(
@@ -88,6 +212,10 @@ type Controller interface {
 		args []interface{}) []interface{}
 }
 
+// DefaultCallHistoryLimit is the number of calls that a controller keeps a
+// record of unless configured otherwise with SetCallHistoryLimit.
+const DefaultCallHistoryLimit = 10000
+
 // methodMap represents a map from method name to set of expectations for that
 // method.
 type methodMap map[string][]*InternalExpectation
@@ -95,17 +223,74 @@ type methodMap map[string][]*InternalExpectation
 // objectMap represents a map from mock object ID to a methodMap for that object.
 type objectMap map[uintptr]methodMap
 
+// defaultMethodMap represents a map from method name to the default behaviors
+// registered for that method with OnCall.
+type defaultMethodMap map[string][]*onCallSpec
+
+// defaultObjectMap represents a map from mock object ID to a defaultMethodMap
+// for that object.
+type defaultObjectMap map[uintptr]defaultMethodMap
+
 // NewController sets up a fresh controller, without any expectations set, and
 // configures the controller to use the supplied error reporter.
 func NewController(reporter ErrorReporter) Controller {
-	return &controllerImpl{reporter, sync.RWMutex{}, objectMap{}}
+	helper := func() {}
+	if hr, ok := reporter.(HelperReporter); ok {
+		helper = hr.HelperFunc()
+	}
+
+	return &controllerImpl{
+		reporter:             reporter,
+		helper:               helper,
+		expectationsByObject: objectMap{},
+		objectsByID:          make(map[uintptr]MockObject),
+		defaultsByObject:     defaultObjectMap{},
+		defaultMode:          Strict,
+		modesByObject:        make(map[uintptr]MockMode),
+		callHistoryLimit:     DefaultCallHistoryLimit,
+	}
+}
+
+// HelperFunc returns a function that marks its caller as a helper for the
+// controller's error reporter, if the reporter supports that (see
+// HelperReporter), or a function that does nothing otherwise. Generated mock
+// methods call it so that failures are attributed to their callers.
+func HelperFunc(c Controller) func() {
+	if c, ok := c.(*controllerImpl); ok {
+		return c.helper
+	}
+
+	return func() {}
 }
 
 type controllerImpl struct {
 	reporter ErrorReporter
 
+	// Marks the calling function as a helper for the reporter.
+	helper func()
+
 	mutex                sync.RWMutex
 	expectationsByObject objectMap // Protected by mutex
+
+	// The mock objects that have appeared in expectationsByObject, keyed by ID,
+	// for use in error messages.
+	objectsByID map[uintptr]MockObject // Protected by mutex
+
+	// Default behaviors registered with OnCall.
+	defaultsByObject defaultObjectMap // Protected by mutex
+
+	// A record of the most recent calls handled, in order, and the number to
+	// keep (or a negative number for no limit).
+	calls            []*Call // Protected by mutex
+	callHistoryLimit int     // Protected by mutex
+
+	// The mode to use for objects that don't appear in modesByObject, and modes
+	// explicitly configured with SetMode, keyed by mock object ID.
+	defaultMode   MockMode             // Protected by mutex
+	modesByObject map[uintptr]MockMode // Protected by mutex
+
+	// Whether Finish has been called.
+	finished bool // Protected by mutex
 }
 
 // Return the list of registered expectations for the named method of the
@@ -148,6 +333,155 @@ func (c *controllerImpl) addExpectationLocked(
 	// Store a modified list.
 	id := o.Oglemock_Id()
 	c.expectationsByObject[id][methodName] = append(existing, exp)
+	c.objectsByID[id] = o
+}
+
+// Return the signature of the named method of the supplied mock object, or nil
+// if it has no such method.
+func methodSignature(o MockObject, methodName string) reflect.Type {
+	if method := reflect.ValueOf(o).MethodByName(methodName); method.IsValid() {
+		return method.Type()
+	}
+
+	if s, ok := o.(unexportedMethodSignaturer); ok {
+		if f := s.Oglemock_MethodSignature(methodName); f != nil {
+			return reflect.TypeOf(f)
+		}
+	}
+
+	return nil
+}
+
+// Return a description of the named method of the supplied mock object for
+// use in error messages, including the mock object's type and description and
+// the method's signature, such as
+// `mock_io.mockReader.Read([]uint8) (int, error) on "reader"`.
+func describeMethod(o MockObject, methodName string) string {
+	t := reflect.TypeOf(o)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var signature string
+	if st := methodSignature(o, methodName); st != nil {
+		signature = strings.TrimPrefix(st.String(), "func")
+	}
+
+	return fmt.Sprintf(
+		"%s.%s%s on %q",
+		t.String(),
+		methodName,
+		signature,
+		o.Oglemock_Description())
+}
+
+// Bring the expected arguments given to ExpectCall or OnCall for a method with
+// the supplied signature into one to one correspondence with its parameters,
+// or return an error if the number of arguments is wrong.
+//
+// For a variadic method, the arguments may end with either a single value or
+// matcher for the variadic slice as a whole, or zero or more values or
+// matchers for its individual elements. In the latter case (or if the former
+// is a non-nil slice) they are combined with ElementsAre. A lone argument in
+// the variadic position is taken to be for the slice as a whole if it is
+// assignable to the slice type. If it is nil or a matcher it is ambiguous, so
+// it matches either the slice as a whole or a slice with a single element.
+func normalizeExpectedArgs(
+	signature reflect.Type,
+	args []interface{}) (normalized []interface{}, err error) {
+	numIn := signature.NumIn()
+
+	// The simple case.
+	if !signature.IsVariadic() {
+		if len(args) != numIn {
+			err = fmt.Errorf("expected %d, got %d", numIn, len(args))
+			return
+		}
+
+		normalized = args
+		return
+	}
+
+	// There must be at least one argument for each non-variadic parameter.
+	if len(args) < numIn-1 {
+		err = fmt.Errorf("expected at least %d, got %d", numIn-1, len(args))
+		return
+	}
+
+	normalized = make([]interface{}, numIn)
+	copy(normalized, args[:numIn-1])
+	variadic := args[numIn-1:]
+
+	// Is there a lone argument for the slice as a whole?
+	if len(variadic) == 1 {
+		x := variadic[0]
+		if m, ok := x.(oglematchers.Matcher); ok {
+			normalized[numIn-1] = &wholeOrSingleElementMatcher{m}
+			return
+		}
+
+		if x == nil {
+			normalized[numIn-1] = &wholeOrSingleElementMatcher{
+				oglematchers.Equals(nil),
+			}
+
+			return
+		}
+
+		if reflect.TypeOf(x).AssignableTo(signature.In(numIn - 1)) {
+			normalized[numIn-1] = x
+
+			// Equals doesn't support non-nil slices.
+			v := reflect.ValueOf(x)
+			if v.Kind() == reflect.Slice && !v.IsNil() {
+				normalized[numIn-1] = elementsAreForSlice(v)
+			}
+
+			return
+		}
+	}
+
+	// Otherwise we have one argument per element.
+	normalized[numIn-1] = oglematchers.ElementsAre(variadic...)
+
+	return
+}
+
+// Return a matcher for slices whose elements equal those of the supplied one.
+func elementsAreForSlice(v reflect.Value) oglematchers.Matcher {
+	elems := make([]interface{}, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i).Interface()
+	}
+
+	return oglematchers.ElementsAre(elems...)
+}
+
+// A matcher for the variadic slice of a call that accepts slices matched by
+// the wrapped matcher, along with slices of a single element matched by it.
+type wholeOrSingleElementMatcher struct {
+	wrapped oglematchers.Matcher
+}
+
+func (m *wholeOrSingleElementMatcher) Matches(c interface{}) error {
+	err := m.wrapped.Matches(c)
+	if err == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(c)
+	if v.Kind() == reflect.Slice && v.Len() == 1 {
+		if m.wrapped.Matches(v.Index(0).Interface()) == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (m *wholeOrSingleElementMatcher) Description() string {
+	desc := m.wrapped.Description()
+	return fmt.Sprintf("%s, or elements are: [%s]", desc, desc)
 }
 
 func (c *controllerImpl) ExpectCall(
@@ -155,19 +489,22 @@ func (c *controllerImpl) ExpectCall(
 	methodName string,
 	fileName string,
 	lineNumber int) PartialExpecation {
+	c.helper()
+
 	// Find the signature for the requested method.
-	ov := reflect.ValueOf(o)
-	method := ov.MethodByName(methodName)
-	if method.Kind() == reflect.Invalid {
+	signature := methodSignature(o, methodName)
+	if signature == nil {
 		c.reporter.ReportFatalError(
 			fileName,
 			lineNumber,
-			errors.New("Unknown method: "+methodName))
+			errors.New("Unknown method: "+describeMethod(o, methodName)))
 		return nil
 	}
 
 	partialAlreadyCalled := false // Protected by c.mutex
 	return func(args ...interface{}) Expectation {
+		c.helper()
+
 		c.mutex.Lock()
 		defer c.mutex.Unlock()
 
@@ -182,26 +519,25 @@ func (c *controllerImpl) ExpectCall(
 
 		partialAlreadyCalled = true
 
-		// Make sure that the number of args is legal. Keep in mind that the
-		// method's type has an extra receiver arg.
-		if len(args) != method.Type().NumIn() {
+		// Make sure that the number of args is legal, and bring them into one to
+		// one correspondence with the method's parameters.
+		args, err := normalizeExpectedArgs(signature, args)
+		if err != nil {
 			c.reporter.ReportFatalError(
 				fileName,
 				lineNumber,
 				errors.New(
 					fmt.Sprintf(
-						"Expectation for %s given wrong number of arguments: "+
-							"expected %d, got %d.",
-						methodName,
-						method.Type().NumIn(),
-						len(args))))
+						"Expectation for %s given wrong number of arguments: %v.",
+						describeMethod(o, methodName),
+						err)))
 			return nil
 		}
 
 		// Create an expectation and insert it into the controller's map.
 		exp := InternalNewExpectation(
 			c.reporter,
-			method.Type(),
+			signature,
 			args,
 			fileName,
 			lineNumber)
@@ -213,42 +549,404 @@ func (c *controllerImpl) ExpectCall(
 	}
 }
 
+func (c *controllerImpl) OnCall(
+	o MockObject,
+	methodName string,
+	fileName string,
+	lineNumber int) PartialOnCall {
+	c.helper()
+
+	// Find the signature for the requested method.
+	signature := methodSignature(o, methodName)
+	if signature == nil {
+		c.reporter.ReportFatalError(
+			fileName,
+			lineNumber,
+			errors.New("Unknown method: "+describeMethod(o, methodName)))
+		return nil
+	}
+
+	partialAlreadyCalled := false // Protected by c.mutex
+	return func(args ...interface{}) DefaultBehavior {
+		c.helper()
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		// This function should only be called once.
+		if partialAlreadyCalled {
+			c.reporter.ReportFatalError(
+				fileName,
+				lineNumber,
+				errors.New("Partial default behavior called more than once."))
+			return nil
+		}
+
+		partialAlreadyCalled = true
+
+		// Make sure that the number of args is legal, and bring them into one to
+		// one correspondence with the method's parameters.
+		args, err := normalizeExpectedArgs(signature, args)
+		if err != nil {
+			c.reporter.ReportFatalError(
+				fileName,
+				lineNumber,
+				errors.New(
+					fmt.Sprintf(
+						"Default behavior for %s given wrong number of arguments: %v.",
+						describeMethod(o, methodName),
+						err)))
+			return nil
+		}
+
+		// Create a spec and insert it into the controller's map.
+		spec := newOnCallSpec(
+			c.reporter,
+			signature,
+			args,
+			fileName,
+			lineNumber)
+
+		id := o.Oglemock_Id()
+		defaultsByMethod, ok := c.defaultsByObject[id]
+		if !ok {
+			defaultsByMethod = defaultMethodMap{}
+			c.defaultsByObject[id] = defaultsByMethod
+		}
+
+		defaultsByMethod[methodName] = append(defaultsByMethod[methodName], spec)
+
+		return spec
+	}
+}
+
 func (c *controllerImpl) Finish() {
+	c.helper()
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	// Check whether the minimum cardinality for each registered expectation has
-	// been satisfied.
+	if c.finished {
+		return
+	}
+
+	c.finished = true
+	c.verifyLocked()
+}
+
+func (c *controllerImpl) Checkpoint() {
+	c.helper()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.verifyLocked()
+
+	var discarded []*InternalExpectation
 	for _, expectationsByMethod := range c.expectationsByObject {
-		for methodName, expectations := range expectationsByMethod {
+		for _, expectations := range expectationsByMethod {
+			discarded = append(discarded, expectations...)
+		}
+	}
+
+	c.expectationsByObject = objectMap{}
+	c.unlinkExpectationsLocked(discarded)
+	c.calls = nil
+}
+
+func (c *controllerImpl) VerifyObject(o MockObject) {
+	c.helper()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id := o.Oglemock_Id()
+	if expectationsByMethod, ok := c.expectationsByObject[id]; ok {
+		c.verifyObjectLocked(id, expectationsByMethod)
+	}
+}
+
+func (c *controllerImpl) ResetObject(o MockObject) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	id := o.Oglemock_Id()
+
+	var discarded []*InternalExpectation
+	for _, expectations := range c.expectationsByObject[id] {
+		discarded = append(discarded, expectations...)
+	}
+
+	delete(c.expectationsByObject, id)
+	c.unlinkExpectationsLocked(discarded)
+
+	var calls []*Call
+	for _, call := range c.calls {
+		if call.Object.Oglemock_Id() != id {
+			calls = append(calls, call)
+		}
+	}
+
+	c.calls = calls
+}
+
+// Remove the supplied expectations, which have just been discarded, from the
+// sequences they belong to. Expectations that remain and followed them in a
+// sequence follow their prerequisites instead.
+//
+// c.mutex must be held for writing.
+func (c *controllerImpl) unlinkExpectationsLocked(
+	discarded []*InternalExpectation) {
+	isDiscarded := make(map[*InternalExpectation]bool)
+	for _, exp := range discarded {
+		isDiscarded[exp] = true
+
+		exp.mutex.Lock()
+		sequences := exp.sequences
+		exp.mutex.Unlock()
+
+		for _, s := range sequences {
+			s.removeExpectation(exp)
+		}
+	}
+
+	for _, expectationsByMethod := range c.expectationsByObject {
+		for _, expectations := range expectationsByMethod {
 			for _, exp := range expectations {
 				exp.mutex.Lock()
-				defer exp.mutex.Unlock()
-
-				minCardinality, _ := computeCardinalityLocked(exp)
-				if exp.NumMatches < minCardinality {
-					c.reporter.ReportError(
-						exp.FileName,
-						exp.LineNumber,
-						errors.New(
-							fmt.Sprintf(
-								"Unsatisfied expectation; expected %s to be called "+
-									"at least %d times; called %d times.",
-								methodName,
-								minCardinality,
-								exp.NumMatches)))
+				prerequisites := exp.Prerequisites
+				exp.mutex.Unlock()
+
+				prerequisites = keptPrerequisitesLocked(prerequisites, isDiscarded)
+
+				exp.mutex.Lock()
+				exp.Prerequisites = prerequisites
+				if isDiscarded[exp.Successor] {
+					exp.Successor = nil
 				}
+				exp.mutex.Unlock()
 			}
 		}
 	}
 }
 
-// expectationMatches checks the matchers for the expectation against the
-// supplied arguments.
-func expectationMatches(exp *InternalExpectation, args []interface{}) bool {
-	matchers := exp.ArgMatchers
+// Return the supplied prerequisites with each discarded one replaced by its
+// own prerequisites, recursively.
+//
+// c.mutex must be held, and the prerequisites' mutexes must not be held.
+func keptPrerequisitesLocked(
+	prerequisites []*InternalExpectation,
+	isDiscarded map[*InternalExpectation]bool) []*InternalExpectation {
+	var kept []*InternalExpectation
+	visited := make(map[*InternalExpectation]bool)
+
+	pending := append([]*InternalExpectation{}, prerequisites...)
+	for len(pending) > 0 {
+		p := pending[0]
+		pending = pending[1:]
+
+		if visited[p] {
+			continue
+		}
+
+		visited[p] = true
+
+		if !isDiscarded[p] {
+			kept = append(kept, p)
+			continue
+		}
+
+		p.mutex.Lock()
+		pending = append(pending, p.Prerequisites...)
+		p.mutex.Unlock()
+	}
+
+	return kept
+}
+
+// Check whether the minimum cardinality for each registered expectation has
+// been satisfied, reporting errors for those that haven't.
+//
+// c.mutex must be held for reading.
+func (c *controllerImpl) verifyLocked() {
+	c.helper()
+
+	for id, expectationsByMethod := range c.expectationsByObject {
+		c.verifyObjectLocked(id, expectationsByMethod)
+	}
+}
+
+// Like verifyLocked, but for the expectations of the single mock object with
+// the given ID.
+//
+// c.mutex must be held for reading.
+func (c *controllerImpl) verifyObjectLocked(
+	id uintptr,
+	expectationsByMethod methodMap) {
+	c.helper()
+
+	for methodName, expectations := range expectationsByMethod {
+		for _, exp := range expectations {
+			exp.mutex.Lock()
+			defer exp.mutex.Unlock()
+
+			minCardinality, maxCardinality := computeCardinalityLocked(exp)
+			if exp.NumMatches < minCardinality {
+				c.reporter.ReportError(
+					exp.FileName,
+					exp.LineNumber,
+					errors.New(
+						fmt.Sprintf(
+							"Unsatisfied expectation; expected %s to be called "+
+								"at least %d times; called %d times (cardinality: %v).%s",
+							describeMethod(c.objectsByID[id], methodName),
+							minCardinality,
+							exp.NumMatches,
+							Between(minCardinality, maxCardinality),
+							c.describeCallsLocked(id, methodName))))
+			}
+		}
+	}
+}
+
+func (c *controllerImpl) WaitForSatisfied(ctx context.Context) error {
+	// Grab the list of expectations. Any registered later are not waited for.
+	c.mutex.RLock()
+	var expectations []*InternalExpectation
+	for _, expectationsByMethod := range c.expectationsByObject {
+		for _, exps := range expectationsByMethod {
+			expectations = append(expectations, exps...)
+		}
+	}
+	c.mutex.RUnlock()
+
+	// Wait for each in turn.
+	for _, exp := range expectations {
+		if !exp.waitForSatisfied(ctx) {
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (c *controllerImpl) FinishWithTimeout(timeout time.Duration) {
+	c.helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Finish will report anything that is still unsatisfied, so there's no need
+	// to look at the result.
+	c.WaitForSatisfied(ctx)
+	c.Finish()
+}
+
+// Return a transcript of the calls made to the named method of the mock object
+// with the given ID, suitable for appending to an error message, or the empty
+// string if there were none.
+//
+// c.mutex must be held for reading.
+func (c *controllerImpl) describeCallsLocked(
+	id uintptr,
+	methodName string) string {
+	var calls CallList
+	for _, call := range c.calls {
+		if call.Object.Oglemock_Id() == id && call.MethodName == methodName {
+			calls = append(calls, *call)
+		}
+	}
+
+	if len(calls) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\nCalls to %s on this object:\n%v", methodName, calls)
+}
+
+func (c *controllerImpl) SetMode(o MockObject, mode MockMode) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.modesByObject[o.Oglemock_Id()] = mode
+}
+
+func (c *controllerImpl) SetDefaultMode(mode MockMode) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.defaultMode = mode
+}
+
+func (c *controllerImpl) Calls() CallList {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	calls := make(CallList, len(c.calls))
+	for i, call := range c.calls {
+		calls[i] = *call
+	}
+
+	return calls
+}
+
+func (c *controllerImpl) SetCallHistoryLimit(n int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.callHistoryLimit = n
+	c.trimCallsLocked()
+}
+
+// Discard the oldest calls recorded, if there are more than the limit.
+//
+// c.mutex must be held for writing.
+func (c *controllerImpl) trimCallsLocked() {
+	if c.callHistoryLimit < 0 || len(c.calls) <= c.callHistoryLimit {
+		return
+	}
+
+	// Clear the discarded entries so that the calls can be collected, even
+	// before append next moves the rest to a new array.
+	excess := len(c.calls) - c.callHistoryLimit
+	for i := 0; i < excess; i++ {
+		c.calls[i] = nil
+	}
+
+	c.calls = c.calls[excess:]
+}
+
+// Return the mode in effect for the supplied mock object.
+//
+// c.mutex must be held for reading.
+func (c *controllerImpl) getModeLocked(o MockObject) MockMode {
+	if mode, ok := c.modesByObject[o.Oglemock_Id()]; ok {
+		return mode
+	}
+
+	return c.defaultMode
+}
+
+// Report a warning using the reporter if it supports warnings, or the standard
+// logger otherwise.
+func (c *controllerImpl) reportWarning(
+	fileName string,
+	lineNumber int,
+	err error) {
+	c.helper()
+
+	if wr, ok := c.reporter.(WarningReporter); ok {
+		wr.ReportWarning(fileName, lineNumber, err)
+		return
+	}
+
+	log.Printf("%s:%d: %v", fileName, lineNumber, err)
+}
+
+// argsMatch checks the supplied matchers against the supplied arguments.
+func argsMatch(matchers []oglematchers.Matcher, args []interface{}) bool {
 	if len(args) != len(matchers) {
-		panic("expectationMatches: len(args)")
+		panic("argsMatch: len(args)")
 	}
 
 	// Check each matcher.
@@ -261,9 +959,40 @@ func expectationMatches(exp *InternalExpectation, args []interface{}) bool {
 	return true
 }
 
+// expectationMatches checks the matchers for the expectation against the
+// supplied arguments.
+func expectationMatches(exp *InternalExpectation, args []interface{}) bool {
+	return argsMatch(exp.ArgMatchers, args)
+}
+
+// Return the action of the most recently registered default behavior for the
+// method that matches the supplied arguments and has an action configured, or
+// nil if there is none.
+//
+// c.mutex must be held for reading.
+func (c *controllerImpl) chooseDefaultActionLocked(
+	o MockObject,
+	methodName string,
+	args []interface{}) Action {
+	specs := c.defaultsByObject[o.Oglemock_Id()][methodName]
+	for i := len(specs) - 1; i >= 0; i-- {
+		if !argsMatch(specs[i].argMatchers, args) {
+			continue
+		}
+
+		if action := specs[i].getAction(); action != nil {
+			return action
+		}
+	}
+
+	return nil
+}
+
 // Return the expectation that matches the supplied arguments. If there is more
 // than one such expectation, the one furthest along in the list for the method
-// is returned. If there is no such expectation, nil is returned.
+// is returned, preferring those whose successors in a sequence haven't yet
+// been matched. Retired expectations are skipped. If there is no such
+// expectation, nil is returned.
 //
 // c.mutex must be held for reading.
 func (c *controllerImpl) chooseExpectationLocked(
@@ -276,15 +1005,98 @@ func (c *controllerImpl) chooseExpectationLocked(
 		return nil
 	}
 
+	var passed *InternalExpectation
 	for i := len(expectations) - 1; i >= 0; i-- {
-		if expectationMatches(expectations[i], args) {
-			return expectations[i]
+		exp := expectations[i]
+
+		exp.mutex.Lock()
+		retired := exp.Retired
+		successor := exp.Successor
+		exp.mutex.Unlock()
+
+		if retired || !expectationMatches(exp, args) {
+			continue
+		}
+
+		if successor == nil {
+			return exp
+		}
+
+		if passed == nil {
+			passed = exp
+		}
+	}
+
+	return passed
+}
+
+// Return an expectation that must be satisfied before the supplied one may be
+// matched but has not yet been, or nil if there is no such expectation.
+// Prerequisites that are satisfied without ever having been matched (e.g.
+// because they have a fallback action) are looked through, so that an optional
+// step in a sequence doesn't excuse the steps before it.
+//
+// c.mutex must be held, and exp.mutex must not be held.
+func findUnsatisfiedPrerequisiteLocked(
+	exp *InternalExpectation) *InternalExpectation {
+	exp.mutex.Lock()
+	pending := append([]*InternalExpectation{}, exp.Prerequisites...)
+	exp.mutex.Unlock()
+
+	visited := make(map[*InternalExpectation]bool)
+	for len(pending) > 0 {
+		p := pending[0]
+		pending = pending[1:]
+
+		if visited[p] {
+			continue
+		}
+
+		visited[p] = true
+
+		p.mutex.Lock()
+		minCardinality, _ := computeCardinalityLocked(p)
+		numMatches := p.NumMatches
+		prerequisites := p.Prerequisites
+		p.mutex.Unlock()
+
+		if numMatches < minCardinality {
+			return p
+		}
+
+		if numMatches == 0 {
+			pending = append(pending, prerequisites...)
 		}
 	}
 
 	return nil
 }
 
+// Record that the supplied expectation has been matched in each of the
+// sequences it belongs to, so that calls matching any of the expectations
+// preceding it are from then on out of order.
+//
+// c.mutex must be held, and exp.mutex must not be held.
+func passPrerequisitesLocked(exp *InternalExpectation) {
+	exp.mutex.Lock()
+	pending := append([]*InternalExpectation{}, exp.Prerequisites...)
+	exp.mutex.Unlock()
+
+	for len(pending) > 0 {
+		p := pending[0]
+		pending = pending[1:]
+
+		// An expectation that has already been passed had its own prerequisites
+		// passed at the same time.
+		p.mutex.Lock()
+		if p.Successor == nil {
+			p.Successor = exp
+			pending = append(pending, p.Prerequisites...)
+		}
+		p.mutex.Unlock()
+	}
+}
+
 // makeZeroReturnValues creates a []interface{} containing appropriate zero
 // values for returning from the supplied method type.
 func makeZeroReturnValues(signature reflect.Type) []interface{} {
@@ -306,6 +1118,12 @@ func makeZeroReturnValues(signature reflect.Type) []interface{} {
 // exp.mutex must be held for reading.
 func computeCardinalityLocked(exp *InternalExpectation) (min, max uint) {
 	// Explicit cardinality.
+	if exp.ExplicitCardinality != nil {
+		min = exp.ExplicitCardinality.min
+		max = exp.ExplicitCardinality.max
+		return
+	}
+
 	if exp.ExpectedNumMatches >= 0 {
 		min = uint(exp.ExpectedNumMatches)
 		max = min
@@ -353,9 +1171,28 @@ func chooseActionLocked(i uint, exp *InternalExpectation) Action {
 	return exp.FallbackAction
 }
 
+// Return a default behavior for the method call if there is one, and zero
+// values otherwise.
+//
+// c.mutex must be held.
+func (c *controllerImpl) chooseDefaultActionOrZeroValuesLocked(
+	o MockObject,
+	methodName string,
+	args []interface{},
+	signature reflect.Type) (action Action, zeroVals []interface{}) {
+	action = c.chooseDefaultActionLocked(o, methodName, args)
+	if action == nil {
+		zeroVals = makeZeroReturnValues(signature)
+	}
+
+	return
+}
+
 // Find an action for the method call, updating expectation match state in the
 // process. Return either an action that should be invoked or a set of zero
-// values to return immediately.
+// values to return immediately, along with the record of the call added to
+// c.calls (or nil if the call was rejected with a fatal error or calls aren't
+// being recorded).
 //
 // This is split out from HandleMethodCall in order to more easily avoid
 // invoking the action with locks held.
@@ -365,18 +1202,19 @@ func (c *controllerImpl) chooseActionAndUpdateExpectations(
 	fileName string,
 	lineNumber int,
 	args []interface{},
-) (action Action, zeroVals []interface{}) {
+) (action Action, zeroVals []interface{}, call *Call) {
+	c.helper()
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	// Find the signature for the requested method.
-	ov := reflect.ValueOf(o)
-	method := ov.MethodByName(methodName)
-	if method.Kind() == reflect.Invalid {
+	signature := methodSignature(o, methodName)
+	if signature == nil {
 		c.reporter.ReportFatalError(
 			fileName,
 			lineNumber,
-			errors.New("Unknown method: "+methodName),
+			errors.New("Unknown method: "+describeMethod(o, methodName)),
 		)
 
 		// Should never get here in real code.
@@ -384,16 +1222,17 @@ func (c *controllerImpl) chooseActionAndUpdateExpectations(
 		return
 	}
 
-	// HACK(jacobsa): Make sure we got the correct number of arguments. This will
-	// need to be refined when issue #5 (variadic methods) is handled.
-	if len(args) != method.Type().NumIn() {
+	// Make sure we got the correct number of arguments. Mock implementations
+	// pass the arguments for a variadic parameter as a single slice.
+	if len(args) != signature.NumIn() {
 		c.reporter.ReportFatalError(
 			fileName,
 			lineNumber,
 			errors.New(
 				fmt.Sprintf(
-					"Wrong number of arguments: expected %d; got %d",
-					method.Type().NumIn(),
+					"Wrong number of arguments to %s: expected %d; got %d",
+					describeMethod(o, methodName),
+					signature.NumIn(),
 					len(args),
 				),
 			),
@@ -404,51 +1243,184 @@ func (c *controllerImpl) chooseActionAndUpdateExpectations(
 		return
 	}
 
+	// Record the call, unless recording has been turned off.
+	if c.callHistoryLimit != 0 {
+		call = &Call{
+			Object:      o,
+			Description: o.Oglemock_Description(),
+			MethodName:  methodName,
+			Args:        args,
+			FileName:    fileName,
+			LineNumber:  lineNumber,
+			Goroutine:   currentGoroutine(),
+			Time:        time.Now(),
+		}
+
+		c.calls = append(c.calls, call)
+		c.trimCallsLocked()
+	}
+
 	// Find an expectation matching this call.
 	expectation := c.chooseExpectationLocked(o, methodName, args)
+
 	if expectation == nil {
+		// If there are no expectations at all for the method, the call is
+		// uninteresting and the object's mode decides whether it is an error.
+		uninteresting := len(c.getExpectationsLocked(o, methodName)) == 0
+		mode := c.getModeLocked(o)
+
+		switch {
+		case uninteresting && mode == Nice:
+
+		case uninteresting && mode == Naggy:
+			c.reportWarning(
+				fileName,
+				lineNumber,
+				errors.New(
+					fmt.Sprintf(
+						"Uninteresting call to %s with args: %v",
+						describeMethod(o, methodName),
+						args,
+					),
+				),
+			)
+
+		default:
+			c.reporter.ReportError(
+				fileName,
+				lineNumber,
+				errors.New(
+					fmt.Sprintf(
+						"Unexpected call to %s with args: %v\n\n%s",
+						describeMethod(o, methodName),
+						args,
+						explainUnexpectedCall(
+							c.getExpectationsLocked(o, methodName),
+							args),
+					),
+				),
+			)
+		}
+
+		action, zeroVals = c.chooseDefaultActionOrZeroValuesLocked(
+			o,
+			methodName,
+			args,
+			signature)
+
+		return
+	}
+
+	// Make sure that the call is not out of order with respect to any sequences
+	// the expectation belongs to, either because a later expectation has
+	// already been matched or because an earlier one hasn't been satisfied.
+	expectation.mutex.Lock()
+	successor := expectation.Successor
+	expectation.mutex.Unlock()
+
+	if successor != nil {
 		c.reporter.ReportError(
 			fileName,
 			lineNumber,
 			errors.New(
-				fmt.Sprintf("Unexpected call to %s with args: %v", methodName, args),
+				fmt.Sprintf(
+					"Out of order call to %s with args: %v; the matching expectation "+
+						"at %s:%d precedes the already matched expectation at %s:%d "+
+						"in a sequence.",
+					describeMethod(o, methodName),
+					args,
+					expectation.FileName,
+					expectation.LineNumber,
+					successor.FileName,
+					successor.LineNumber,
+				),
 			),
 		)
 
-		zeroVals = makeZeroReturnValues(method.Type())
+		action, zeroVals = c.chooseDefaultActionOrZeroValuesLocked(
+			o,
+			methodName,
+			args,
+			signature)
+
 		return
 	}
 
+	if prev := findUnsatisfiedPrerequisiteLocked(expectation); prev != nil {
+		c.reporter.ReportError(
+			fileName,
+			lineNumber,
+			errors.New(
+				fmt.Sprintf(
+					"Out of order call to %s with args: %v; the matching expectation "+
+						"at %s:%d follows the unsatisfied expectation at %s:%d "+
+						"in a sequence.",
+					describeMethod(o, methodName),
+					args,
+					expectation.FileName,
+					expectation.LineNumber,
+					prev.FileName,
+					prev.LineNumber,
+				),
+			),
+		)
+
+		action, zeroVals = c.chooseDefaultActionOrZeroValuesLocked(
+			o,
+			methodName,
+			args,
+			signature)
+
+		return
+	}
+
+	passPrerequisitesLocked(expectation)
+
 	expectation.mutex.Lock()
 	defer expectation.mutex.Unlock()
 
 	// Increase the number of matches recorded, and check whether we're over the
 	// number expected.
-	expectation.NumMatches++
-	_, maxCardinality := computeCardinalityLocked(expectation)
+	expectation.recordMatchLocked()
+	if call != nil {
+		call.Expectation = expectation
+	}
+
+	minCardinality, maxCardinality := computeCardinalityLocked(expectation)
 	if expectation.NumMatches > maxCardinality {
 		c.reporter.ReportError(
 			expectation.FileName,
 			expectation.LineNumber,
 			errors.New(
 				fmt.Sprintf(
-					"Unexpected call to %s: "+
-						"expected to be called at most %d times; called %d times.",
-					methodName,
+					"Unexpected call to %s: expected to be called at most %d times; "+
+						"called %d times (cardinality: %v).",
+					describeMethod(o, methodName),
 					maxCardinality,
 					expectation.NumMatches,
+					Between(minCardinality, maxCardinality),
 				),
 			),
 		)
 
-		zeroVals = makeZeroReturnValues(method.Type())
+		zeroVals = makeZeroReturnValues(signature)
 		return
 	}
 
-	// Choose an action to invoke. If there is none, just return zero values.
+	// Retire the expectation if it asked for it and can't match any more.
+	if expectation.RetireOnSaturation && expectation.NumMatches == maxCardinality {
+		expectation.Retired = true
+	}
+
+	// Choose an action to invoke. If there is none, fall back to a default
+	// behavior if there is one, and zero values otherwise.
 	action = chooseActionLocked(expectation.NumMatches-1, expectation)
 	if action == nil {
-		zeroVals = makeZeroReturnValues(method.Type())
+		action = c.chooseDefaultActionLocked(o, methodName, args)
+	}
+
+	if action == nil {
+		zeroVals = makeZeroReturnValues(signature)
 		return
 	}
 
@@ -463,8 +1435,10 @@ func (c *controllerImpl) HandleMethodCall(
 	lineNumber int,
 	args []interface{},
 ) []interface{} {
+	c.helper()
+
 	// Figure out whether to invoke an action or return zero values.
-	action, zeroVals := c.chooseActionAndUpdateExpectations(
+	action, zeroVals, call := c.chooseActionAndUpdateExpectations(
 		o,
 		methodName,
 		fileName,
@@ -472,9 +1446,17 @@ func (c *controllerImpl) HandleMethodCall(
 		args,
 	)
 
+	retVals := zeroVals
 	if action != nil {
-		return action.Invoke(args)
+		retVals = action.Invoke(args)
+	}
+
+	// Record the values returned.
+	if call != nil {
+		c.mutex.Lock()
+		call.Returns = retVals
+		c.mutex.Unlock()
 	}
 
-	return zeroVals
+	return retVals
 }
@@ -16,10 +16,12 @@
 package oglemock_test
 
 import (
+	"context"
 	. "github.com/jacobsa/oglematchers"
 	. "github.com/jacobsa/oglemock"
 	. "github.com/jacobsa/ogletest"
 	"reflect"
+	"time"
 )
 
 ////////////////////////////////////////////////////////////
@@ -35,6 +37,7 @@ type errorReport struct {
 type fakeErrorReporter struct {
 	errors      []errorReport
 	fatalErrors []errorReport
+	warnings    []errorReport
 }
 
 func (r *fakeErrorReporter) ReportError(fileName string, lineNumber int, err error) {
@@ -47,6 +50,11 @@ func (r *fakeErrorReporter) ReportFatalError(fileName string, lineNumber int, er
 	r.fatalErrors = append(r.fatalErrors, report)
 }
 
+func (r *fakeErrorReporter) ReportWarning(fileName string, lineNumber int, err error) {
+	report := errorReport{fileName, lineNumber, err}
+	r.warnings = append(r.warnings, report)
+}
+
 type trivialMockObject struct {
 	id   uintptr
 	desc string
@@ -70,6 +78,32 @@ func (o *trivialMockObject) TwoIntsToString(i, j int) string {
 	return ""
 }
 
+// Method being mocked
+func (o *trivialMockObject) Sprintf(format string, a ...interface{}) string {
+	return ""
+}
+
+// A mock object for an interface with an unexported method, which reports the
+// method's signature in the same way as generated mocks do.
+type unexportedMethodMockObject struct {
+	trivialMockObject
+}
+
+// Method being mocked
+func (o *unexportedMethodMockObject) stringToInt(s string) int {
+	return 0
+}
+
+func (o *unexportedMethodMockObject) Oglemock_MethodSignature(
+	methodName string) interface{} {
+	switch methodName {
+	case "stringToInt":
+		return (func(string) int)(nil)
+	}
+
+	return nil
+}
+
 type ControllerTest struct {
 	reporter   fakeErrorReporter
 	controller Controller
@@ -99,6 +133,22 @@ func (t *ControllerTest) FinishWithoutAnyEvents() {
 	ExpectEq(0, len(t.reporter.fatalErrors))
 }
 
+func (t *ControllerTest) FinishCalledTwice() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("")
+
+	t.controller.Finish()
+	t.controller.Finish()
+
+	// The expectation should be reported only once.
+	ExpectEq(1, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) HelperFuncWithoutHelperReporter() {
+	// The reporter doesn't implement HelperReporter, so this does nothing.
+	HelperFunc(t.controller)()
+}
+
 func (t *ControllerTest) HandleCallForUnknownObject() {
 	p := []byte{255}
 	t.controller.HandleMethodCall(
@@ -1247,3 +1297,1410 @@ func (t *ControllerTest) ActionCallsAgainMatchingSameExpectation() {
 	ExpectThat(t.reporter.errors, ElementsAre())
 	ExpectThat(t.reporter.fatalErrors, ElementsAre())
 }
+
+func (t *ControllerTest) SequenceCalledInOrder() {
+	seq := NewSequence()
+
+	// Expectations, spanning two mock objects.
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("").
+		WillOnce(Return(17)).
+		InSequence(seq)
+
+	t.controller.ExpectCall(t.mock2, "TwoIntsToString", "burrito.go", 118)(1, 2).
+		WillOnce(Return("taco")).
+		InSequence(seq)
+
+	// Calls
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{""})
+
+	AssertThat(rets, ElementsAre(17))
+
+	rets = t.controller.HandleMethodCall(
+		t.mock2,
+		"TwoIntsToString",
+		"",
+		0,
+		[]interface{}{1, 2})
+
+	AssertThat(rets, ElementsAre("taco"))
+
+	// Finish
+	t.controller.Finish()
+
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	ExpectEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *ControllerTest) SequenceCalledOutOfOrder() {
+	seq := NewSequence()
+
+	// Expectations
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("").
+		WillOnce(Return(17)).
+		InSequence(seq)
+
+	t.controller.ExpectCall(t.mock2, "TwoIntsToString", "burrito.go", 118)(1, 2).
+		WillOnce(Return("taco")).
+		InSequence(seq)
+
+	// Call the second method first.
+	rets := t.controller.HandleMethodCall(
+		t.mock2,
+		"TwoIntsToString",
+		"taco.go",
+		112,
+		[]interface{}{1, 2})
+
+	ExpectThat(rets, ElementsAre(""))
+
+	// The error should be reported immediately, at the call site.
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	r := t.reporter.errors[0]
+	ExpectEq("taco.go", r.fileName)
+	ExpectEq(112, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("Out of order")))
+	ExpectThat(r.err, Error(HasSubstr("TwoIntsToString")))
+	ExpectThat(r.err, Error(HasSubstr("[1 2]")))
+	ExpectThat(r.err, Error(HasSubstr("burrito.go:118")))
+	ExpectThat(r.err, Error(HasSubstr("burrito.go:117")))
+
+	// The out of order call shouldn't count as a match.
+	rets = t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{""})
+
+	ExpectThat(rets, ElementsAre(17))
+
+	rets = t.controller.HandleMethodCall(
+		t.mock2,
+		"TwoIntsToString",
+		"",
+		0,
+		[]interface{}{1, 2})
+
+	ExpectThat(rets, ElementsAre("taco"))
+
+	t.controller.Finish()
+	ExpectEq(1, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) SequenceLooksThroughOptionalExpectations() {
+	seq := NewSequence()
+
+	// Expectations -- the middle one is optional.
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a").
+		InSequence(seq)
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 118)("b").
+		WillRepeatedly(Return(0)).
+		InSequence(seq)
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 119)("c").
+		InSequence(seq)
+
+	// Calling the last one first should complain about the first.
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"c"})
+
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	r := t.reporter.errors[0]
+	ExpectThat(r.err, Error(HasSubstr("Out of order")))
+	ExpectThat(r.err, Error(HasSubstr("burrito.go:117")))
+
+	// Skipping the optional one should be fine.
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"a"})
+
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"c"})
+
+	t.controller.Finish()
+	ExpectEq(1, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) SequenceCalledAgainAfterLaterStep() {
+	seq := NewSequence()
+
+	// Expectations -- the first may be matched any number of times.
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("open").
+		Cardinality(AnyNumber()).
+		InSequence(seq)
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 118)("close").
+		InSequence(seq)
+
+	// Calls
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"open"})
+
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"close"})
+
+	AssertEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+
+	// Matching the first expectation again is now out of order.
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"open"})
+
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	r := t.reporter.errors[0]
+	ExpectEq("taco.go", r.fileName)
+	ExpectEq(112, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("Out of order")))
+	ExpectThat(r.err, Error(HasSubstr("[open]")))
+	ExpectThat(r.err, Error(HasSubstr("burrito.go:117")))
+	ExpectThat(r.err, Error(HasSubstr("burrito.go:118")))
+
+	t.controller.Finish()
+	ExpectEq(1, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) SequenceCalledOutOfOrder_UsesDefaultAction() {
+	seq := NewSequence()
+
+	// Expectations and a default behavior.
+	t.controller.OnCall(t.mock1, "StringToInt", "burrito.go", 116)(Any()).
+		WillByDefault(Return(19))
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a").
+		WillOnce(Return(17)).
+		InSequence(seq)
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 118)("b").
+		WillOnce(Return(23)).
+		InSequence(seq)
+
+	// Calling the second one first should report an error and use the default
+	// action.
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"b"})
+
+	ExpectThat(rets, ElementsAre(19))
+	AssertEq(1, len(t.reporter.errors))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Out of order")))
+}
+
+func (t *ControllerTest) UnexpectedCallExplainsCandidates() {
+	// Expectations -- the second is the closer match.
+	t.controller.ExpectCall(t.mock1, "TwoIntsToString", "burrito.go", 117)(
+		LessThan(5), Equals(7))
+
+	t.controller.ExpectCall(t.mock1, "TwoIntsToString", "burrito.go", 118)(
+		LessThan(10), Equals(2))
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 119)("")
+
+	// Call
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"TwoIntsToString",
+		"taco.go",
+		112,
+		[]interface{}{8, 1})
+
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	r := t.reporter.errors[0]
+	ExpectThat(r.err, Error(HasSubstr("Unexpected call to oglemock_test.trivialMockObject.TwoIntsToString")))
+	ExpectThat(r.err, Error(HasSubstr("[8 1]")))
+	ExpectThat(r.err, Error(HasSubstr("Tried 2 expectation(s)")))
+	ExpectThat(r.err, Error(Not(HasSubstr("burrito.go:119"))))
+
+	// The closer candidate should come first.
+	ExpectThat(
+		r.err,
+		Error(
+			MatchesRegexp(
+				"burrito.go:118:\n"+
+					"    arg 0: matches less than 10\n"+
+					"    arg 1: expected 2, but was 1\n"+
+					"\n"+
+					"  burrito.go:117:\n"+
+					"    arg 0: expected less than 5, but was 8\n"+
+					"    arg 1: expected 7, but was 1")))
+}
+
+func (t *ControllerTest) UnexpectedCallWithNoCandidates() {
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{""})
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectThat(
+		t.reporter.errors[0].err,
+		Error(HasSubstr("no expectations registered")))
+}
+
+func (t *ControllerTest) StrictModeIsDefault() {
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{""})
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+	ExpectEq(0, len(t.reporter.warnings))
+
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unexpected")))
+}
+
+func (t *ControllerTest) NaggyModeWarnsForUninterestingCall() {
+	t.controller.SetMode(t.mock1, Naggy)
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"foo"})
+
+	ExpectThat(rets, ElementsAre(0))
+
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+	AssertEq(1, len(t.reporter.warnings))
+
+	r := t.reporter.warnings[0]
+	ExpectEq("taco.go", r.fileName)
+	ExpectEq(112, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("Uninteresting call to oglemock_test.trivialMockObject.StringToInt")))
+	ExpectThat(r.err, Error(HasSubstr("[foo]")))
+
+	// Other objects should be unaffected.
+	t.controller.HandleMethodCall(
+		t.mock2,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"foo"})
+
+	ExpectEq(1, len(t.reporter.errors))
+}
+
+func (t *ControllerTest) NiceModeAllowsUninterestingCall() {
+	t.controller.SetDefaultMode(Nice)
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"TwoIntsToString",
+		"taco.go",
+		112,
+		[]interface{}{1, 2})
+
+	ExpectThat(rets, ElementsAre(""))
+
+	t.controller.Finish()
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+	ExpectEq(0, len(t.reporter.warnings))
+}
+
+func (t *ControllerTest) NiceModeStillReportsUnexpectedCall() {
+	t.controller.SetMode(t.mock1, Nice)
+
+	// An expectation for the method makes calls to it interesting.
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("bar").
+		WillRepeatedly(Return(17))
+
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"foo"})
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.warnings))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unexpected")))
+}
+
+func (t *ControllerTest) ModeConfiguredWithOption() {
+	WithMode(Nice)(t.controller, t.mock1)
+
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"foo"})
+
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.warnings))
+}
+
+func (t *ControllerTest) OnCallForUnknownMethod() {
+	ExpectEq(
+		nil,
+		t.controller.OnCall(t.mock1, "Frobnicate", "burrito.go", 117))
+
+	AssertEq(0, len(t.reporter.errors))
+	AssertEq(1, len(t.reporter.fatalErrors))
+
+	report := t.reporter.fatalErrors[0]
+	ExpectEq("burrito.go", report.fileName)
+	ExpectEq(117, report.lineNumber)
+	ExpectThat(report.err, Error(HasSubstr("Unknown method")))
+	ExpectThat(report.err, Error(HasSubstr("Frobnicate")))
+}
+
+func (t *ControllerTest) OnCallGivenWrongNumberOfArgs() {
+	ExpectEq(
+		nil,
+		t.controller.OnCall(t.mock1, "TwoIntsToString", "burrito.go", 117)(17))
+
+	AssertEq(0, len(t.reporter.errors))
+	AssertEq(1, len(t.reporter.fatalErrors))
+
+	report := t.reporter.fatalErrors[0]
+	ExpectEq("burrito.go", report.fileName)
+	ExpectEq(117, report.lineNumber)
+	ExpectThat(report.err, Error(HasSubstr("TwoIntsToString")))
+	ExpectThat(report.err, Error(HasSubstr("expected 2")))
+	ExpectThat(report.err, Error(HasSubstr("got 1")))
+}
+
+func (t *ControllerTest) WillByDefaultGivenInvalidAction() {
+	t.controller.OnCall(t.mock1, "StringToInt", "burrito.go", 117)(Any()).
+		WillByDefault(Return("taco"))
+
+	AssertEq(0, len(t.reporter.errors))
+	AssertEq(1, len(t.reporter.fatalErrors))
+
+	report := t.reporter.fatalErrors[0]
+	ExpectEq("burrito.go", report.fileName)
+	ExpectEq(117, report.lineNumber)
+	ExpectThat(report.err, Error(HasSubstr("WillByDefault")))
+	ExpectThat(report.err, Error(HasSubstr("string")))
+}
+
+func (t *ControllerTest) OnCallUsedForUninterestingCall() {
+	t.controller.SetMode(t.mock1, Nice)
+
+	t.controller.OnCall(t.mock1, "StringToInt", "", 0)(Any()).
+		WillByDefault(Return(17))
+
+	t.controller.OnCall(t.mock1, "StringToInt", "", 0)("taco").
+		WillByDefault(Return(19))
+
+	// The most recent matching default should win.
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"taco"})
+
+	ExpectThat(rets, ElementsAre(19))
+
+	rets = t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"burrito"})
+
+	ExpectThat(rets, ElementsAre(17))
+
+	// Defaults impose no cardinality.
+	t.controller.Finish()
+
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) OnCallUsedForExpectationWithoutAction() {
+	t.controller.OnCall(t.mock1, "StringToInt", "", 0)(Any()).
+		WillByDefault(Return(17))
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "", 0)(Any()).
+		Times(2)
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "", 0)("taco").
+		WillOnce(Return(19))
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"taco"})
+
+	ExpectThat(rets, ElementsAre(19))
+
+	rets = t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"burrito"})
+
+	ExpectThat(rets, ElementsAre(17))
+
+	rets = t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"enchilada"})
+
+	ExpectThat(rets, ElementsAre(17))
+
+	t.controller.Finish()
+
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) OnCallDoesNotMakeCallExpected() {
+	t.controller.OnCall(t.mock1, "StringToInt", "", 0)(Any()).
+		WillByDefault(Return(17))
+
+	// The object is strict, so the call is still an error. But the default
+	// action should be used for the return value.
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"taco"})
+
+	ExpectThat(rets, ElementsAre(17))
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unexpected")))
+}
+
+func (t *ControllerTest) AtLeastCardinalityNotSatisfied() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)(Any()).
+		Cardinality(AtLeast(3)).
+		WillRepeatedly(Return(17))
+
+	// Call twice.
+	for i := 0; i < 2; i++ {
+		rets := t.controller.HandleMethodCall(
+			t.mock1,
+			"StringToInt",
+			"",
+			0,
+			[]interface{}{""})
+
+		ExpectThat(rets, ElementsAre(17))
+	}
+
+	// Finish should cause the error to be reported.
+	t.controller.Finish()
+
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	r := t.reporter.errors[0]
+	ExpectEq("burrito.go", r.fileName)
+	ExpectEq(117, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("Unsatisfied")))
+	ExpectThat(r.err, Error(HasSubstr("at least 3 times")))
+	ExpectThat(r.err, Error(HasSubstr("called 2 times")))
+}
+
+func (t *ControllerTest) AtLeastCardinalitySatisfied() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)(Any()).
+		Cardinality(AtLeast(3))
+
+	// Call five times.
+	for i := 0; i < 5; i++ {
+		t.controller.HandleMethodCall(
+			t.mock1,
+			"StringToInt",
+			"",
+			0,
+			[]interface{}{""})
+	}
+
+	t.controller.Finish()
+
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) BetweenCardinalityOverrun() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)(Any()).
+		Cardinality(Between(1, 2))
+
+	// Call three times.
+	for i := 0; i < 3; i++ {
+		t.controller.HandleMethodCall(
+			t.mock1,
+			"StringToInt",
+			"",
+			0,
+			[]interface{}{""})
+	}
+
+	// The error should be reported immediately.
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	r := t.reporter.errors[0]
+	ExpectEq("burrito.go", r.fileName)
+	ExpectEq(117, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("Unexpected")))
+	ExpectThat(r.err, Error(HasSubstr("at most 2 times")))
+	ExpectThat(r.err, Error(HasSubstr("called 3 times")))
+	ExpectThat(r.err, Error(HasSubstr("between 1 and 2 times")))
+
+	// Finish should change nothing.
+	t.controller.Finish()
+
+	ExpectEq(1, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) AnyNumberCardinalityWithZeroCalls() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)(Any()).
+		Cardinality(AnyNumber())
+
+	t.controller.Finish()
+
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) RetiresOnSaturation() {
+	// Expectations -- a generic fallback, and a more specific one that retires.
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)(Any()).
+		WillRepeatedly(Return(0))
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 118)(Any()).
+		WillOnce(Return(17)).
+		WillOnce(Return(19)).
+		RetiresOnSaturation()
+
+	// Calls
+	var rets []interface{}
+	call := func() {
+		rets = t.controller.HandleMethodCall(
+			t.mock1,
+			"StringToInt",
+			"",
+			0,
+			[]interface{}{""})
+	}
+
+	call()
+	ExpectThat(rets, ElementsAre(17))
+
+	call()
+	ExpectThat(rets, ElementsAre(19))
+
+	call()
+	ExpectThat(rets, ElementsAre(0))
+
+	call()
+	ExpectThat(rets, ElementsAre(0))
+
+	// Finish
+	t.controller.Finish()
+
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	ExpectEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *ControllerTest) RetiredExpectationWithNothingToFallBackTo() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)(Any()).
+		RetiresOnSaturation()
+
+	// The first call matches; the second is unexpected.
+	for i := 0; i < 2; i++ {
+		t.controller.HandleMethodCall(
+			t.mock1,
+			"StringToInt",
+			"taco.go",
+			112,
+			[]interface{}{""})
+	}
+
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	r := t.reporter.errors[0]
+	ExpectEq("taco.go", r.fileName)
+	ExpectEq(112, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("Unexpected call to oglemock_test.trivialMockObject.StringToInt")))
+	ExpectThat(r.err, Error(HasSubstr("burrito.go:117: (retired)")))
+}
+
+func (t *ControllerTest) VariadicExpectationGivenTooFewArgs() {
+	ExpectEq(
+		nil,
+		t.controller.ExpectCall(t.mock1, "Sprintf", "burrito.go", 117)())
+
+	AssertEq(0, len(t.reporter.errors))
+	AssertEq(1, len(t.reporter.fatalErrors))
+
+	report := t.reporter.fatalErrors[0]
+	ExpectEq("burrito.go", report.fileName)
+	ExpectEq(117, report.lineNumber)
+	ExpectThat(report.err, Error(HasSubstr("Sprintf")))
+	ExpectThat(report.err, Error(HasSubstr("arguments")))
+	ExpectThat(report.err, Error(HasSubstr("expected at least 1")))
+	ExpectThat(report.err, Error(HasSubstr("got 0")))
+}
+
+func (t *ControllerTest) VariadicExpectationWithMatcherForWholeSlice() {
+	t.controller.ExpectCall(t.mock1, "Sprintf", "", 0)("taco", ElementsAre(1, 2)).
+		WillOnce(Return("burrito"))
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"",
+		0,
+		[]interface{}{"taco", []interface{}{1, 2}})
+
+	ExpectThat(rets, ElementsAre("burrito"))
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	ExpectEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *ControllerTest) VariadicExpectationWithMatcherForSingleElement() {
+	t.controller.ExpectCall(t.mock1, "Sprintf", "", 0)("%d", Equals(1)).
+		WillRepeatedly(Return("burrito"))
+
+	// Matching
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"",
+		0,
+		[]interface{}{"%d", []interface{}{1}})
+
+	ExpectThat(rets, ElementsAre("burrito"))
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+
+	// Wrong element
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"taco.go",
+		112,
+		[]interface{}{"%d", []interface{}{2}})
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unexpected")))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("elements are: [1]")))
+
+	// Too many elements
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"taco.go",
+		113,
+		[]interface{}{"%d", []interface{}{1, 1}})
+
+	AssertEq(2, len(t.reporter.errors))
+	ExpectThat(t.reporter.errors[1].err, Error(HasSubstr("Unexpected")))
+}
+
+func (t *ControllerTest) VariadicExpectationWithNilForSingleElement() {
+	t.controller.ExpectCall(t.mock1, "Sprintf", "", 0)("%v", nil).
+		WillRepeatedly(Return("burrito"))
+
+	// A nil slice
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"",
+		0,
+		[]interface{}{"%v", []interface{}(nil)})
+
+	ExpectThat(rets, ElementsAre("burrito"))
+
+	// A single nil element
+	rets = t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"",
+		0,
+		[]interface{}{"%v", []interface{}{nil}})
+
+	ExpectThat(rets, ElementsAre("burrito"))
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	ExpectEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *ControllerTest) VariadicExpectationWithSliceValue() {
+	t.controller.ExpectCall(t.mock1, "Sprintf", "", 0)("taco", []interface{}{1, 2}).
+		WillOnce(Return("burrito"))
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"",
+		0,
+		[]interface{}{"taco", []interface{}{1, 2}})
+
+	ExpectThat(rets, ElementsAre("burrito"))
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	ExpectEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *ControllerTest) VariadicExpectationWithOneArgPerElement() {
+	t.controller.ExpectCall(t.mock1, "Sprintf", "", 0)("taco", 1, LessThan(3)).
+		WillRepeatedly(Return("burrito"))
+
+	// Matching
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"",
+		0,
+		[]interface{}{"taco", []interface{}{1, 2}})
+
+	ExpectThat(rets, ElementsAre("burrito"))
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+
+	// Wrong number of elements
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"taco.go",
+		112,
+		[]interface{}{"taco", []interface{}{1, 2, 3}})
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unexpected")))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("length 3")))
+}
+
+func (t *ControllerTest) VariadicExpectationWithNoElements() {
+	t.controller.ExpectCall(t.mock1, "Sprintf", "", 0)("taco").
+		WillOnce(Return("burrito"))
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"",
+		0,
+		[]interface{}{"taco", []interface{}(nil)})
+
+	ExpectThat(rets, ElementsAre("burrito"))
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	ExpectEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *ControllerTest) VariadicMethodWithInvokeAndSaveArg() {
+	var saved []interface{}
+	f := func(format string, a ...interface{}) string {
+		return format + "!"
+	}
+
+	t.controller.ExpectCall(t.mock1, "Sprintf", "", 0)("taco", 1, 2).
+		WillOnce(DoAll(SaveArg(1, &saved), Invoke(f)))
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"Sprintf",
+		"",
+		0,
+		[]interface{}{"taco", []interface{}{1, 2}})
+
+	ExpectThat(rets, ElementsAre("taco!"))
+	ExpectThat(saved, ElementsAre(1, 2))
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	ExpectEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *ControllerTest) RecordsCalls() {
+	exp := t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a").
+		WillOnce(Return(17))
+
+	// Calls
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"a"})
+
+	t.controller.HandleMethodCall(
+		t.mock2,
+		"TwoIntsToString",
+		"taco.go",
+		113,
+		[]interface{}{1, 2})
+
+	// Check the record.
+	calls := t.controller.Calls()
+	AssertEq(2, len(calls))
+
+	c := calls[0]
+	ExpectEq(t.mock1, c.Object)
+	ExpectEq("taco", c.Description)
+	ExpectEq("StringToInt", c.MethodName)
+	ExpectThat(c.Args, ElementsAre("a"))
+	ExpectThat(c.Returns, ElementsAre(17))
+	ExpectEq("taco.go", c.FileName)
+	ExpectEq(112, c.LineNumber)
+	ExpectNe(0, c.Goroutine)
+	ExpectFalse(c.Time.IsZero())
+	ExpectEq(exp, c.Expectation)
+
+	c = calls[1]
+	ExpectEq(t.mock2, c.Object)
+	ExpectEq("burrito", c.Description)
+	ExpectEq("TwoIntsToString", c.MethodName)
+	ExpectThat(c.Args, ElementsAre(1, 2))
+	ExpectThat(c.Returns, ElementsAre(""))
+	ExpectEq(113, c.LineNumber)
+	ExpectEq(nil, c.Expectation)
+
+	ExpectThat(
+		calls.String(),
+		Equals(
+			`taco.go:112: StringToInt("a") on "taco" returned (17) `+
+				"[expectation at burrito.go:117]\n"+
+				`taco.go:113: TwoIntsToString(1, 2) on "burrito" returned ("") `+
+				"[unexpected]"))
+}
+
+func (t *ControllerTest) FiltersCalls() {
+	t.controller.SetDefaultMode(Nice)
+
+	// Calls
+	args := [][]interface{}{{"a"}, {"b"}, {"c"}}
+	for _, a := range args {
+		t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, a)
+		t.controller.HandleMethodCall(t.mock2, "StringToInt", "", 0, a)
+	}
+
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"TwoIntsToString",
+		"",
+		0,
+		[]interface{}{1, 2})
+
+	calls := t.controller.Calls()
+	ExpectEq(7, len(calls))
+	ExpectEq(4, len(calls.ForObject(t.mock1)))
+	ExpectEq(3, len(calls.ForObject(t.mock2)))
+	ExpectEq(6, len(calls.ForMethod("StringToInt")))
+	ExpectEq(7, len(calls.Unexpected()))
+
+	filtered := calls.ForObject(t.mock2).ForMethod("StringToInt")
+	AssertEq(3, len(filtered))
+	ExpectThat(filtered[0].Args, ElementsAre("a"))
+	ExpectThat(filtered[1].Args, ElementsAre("b"))
+	ExpectThat(filtered[2].Args, ElementsAre("c"))
+}
+
+func (t *ControllerTest) CallHistoryIsLimited() {
+	t.controller.SetDefaultMode(Nice)
+	t.controller.SetCallHistoryLimit(2)
+
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"a"})
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"b"})
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"c"})
+
+	// Only the most recent calls should be kept.
+	calls := t.controller.Calls()
+	AssertEq(2, len(calls))
+	ExpectThat(calls[0].Args, ElementsAre("b"))
+	ExpectThat(calls[1].Args, ElementsAre("c"))
+
+	// Lowering the limit should discard more.
+	t.controller.SetCallHistoryLimit(1)
+
+	calls = t.controller.Calls()
+	AssertEq(1, len(calls))
+	ExpectThat(calls[0].Args, ElementsAre("c"))
+
+	// Removing it should keep everything from now on.
+	t.controller.SetCallHistoryLimit(-1)
+	for i := 0; i < DefaultCallHistoryLimit; i++ {
+		t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"d"})
+	}
+
+	ExpectEq(DefaultCallHistoryLimit+1, len(t.controller.Calls()))
+}
+
+func (t *ControllerTest) CallHistoryLimitOfZero() {
+	exp := t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a").
+		Times(3).
+		WillRepeatedly(Return(17))
+
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"a"})
+
+	// Turn recording off. The call recorded already should be discarded, and
+	// calls should still be handled.
+	t.controller.SetCallHistoryLimit(0)
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"a"})
+
+	ExpectThat(rets, ElementsAre(17))
+	ExpectEq(0, len(t.controller.Calls()))
+
+	// Turn it back on.
+	t.controller.SetCallHistoryLimit(DefaultCallHistoryLimit)
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"a"})
+
+	calls := t.controller.Calls()
+	AssertEq(1, len(calls))
+	ExpectEq(exp, calls[0].Expectation)
+
+	t.controller.Finish()
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+}
+
+func (t *ControllerTest) UnsatisfiedExpectationIncludesTranscript() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a").
+		Times(2)
+
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"a"})
+
+	t.controller.Finish()
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectThat(
+		t.reporter.errors[0].err,
+		Error(HasSubstr("Calls to StringToInt on this object:\ntaco.go:112: ")))
+}
+
+func (t *ControllerTest) ExpectationWaitSatisfiedInBackground() {
+	exp := t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("").
+		Times(2)
+
+	// Make the calls from another goroutine, after a delay.
+	go func() {
+		for i := 0; i < 2; i++ {
+			time.Sleep(10 * time.Millisecond)
+			t.controller.HandleMethodCall(
+				t.mock1,
+				"StringToInt",
+				"",
+				0,
+				[]interface{}{""})
+		}
+	}()
+
+	ExpectTrue(exp.Wait(10 * time.Second))
+
+	t.controller.Finish()
+	ExpectEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+}
+
+func (t *ControllerTest) ExpectationWaitTimesOut() {
+	exp := t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("")
+
+	ExpectFalse(exp.Wait(10 * time.Millisecond))
+}
+
+func (t *ControllerTest) WaitForSatisfied() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("")
+	t.controller.ExpectCall(t.mock2, "TwoIntsToString", "burrito.go", 118)(1, 2)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		t.controller.HandleMethodCall(
+			t.mock2,
+			"TwoIntsToString",
+			"",
+			0,
+			[]interface{}{1, 2})
+
+		t.controller.HandleMethodCall(
+			t.mock1,
+			"StringToInt",
+			"",
+			0,
+			[]interface{}{""})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ExpectEq(nil, t.controller.WaitForSatisfied(ctx))
+}
+
+func (t *ControllerTest) WaitForSatisfiedCancelled() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	ExpectThat(t.controller.WaitForSatisfied(ctx), Error(HasSubstr("deadline")))
+}
+
+func (t *ControllerTest) FinishWithTimeout() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("")
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 118)("a")
+
+	// Satisfy only the first expectation, in the background, and wait for the
+	// call to be handled so that only the second can be reported.
+	handled := make(chan struct{})
+	go func() {
+		t.controller.HandleMethodCall(
+			t.mock1,
+			"StringToInt",
+			"",
+			0,
+			[]interface{}{""})
+
+		close(handled)
+	}()
+
+	<-handled
+	t.controller.FinishWithTimeout(10 * time.Millisecond)
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectEq(118, t.reporter.errors[0].lineNumber)
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unsatisfied")))
+}
+
+func (t *ControllerTest) CheckpointReportsAndClearsExpectations() {
+	// Phase one -- one satisfied expectation and one unsatisfied one.
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a").
+		WillOnce(Return(17))
+
+	t.controller.ExpectCall(t.mock2, "StringToInt", "burrito.go", 118)("b")
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"a"})
+
+	ExpectThat(rets, ElementsAre(17))
+
+	t.controller.Checkpoint()
+
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	ExpectEq("burrito.go", t.reporter.errors[0].fileName)
+	ExpectEq(118, t.reporter.errors[0].lineNumber)
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unsatisfied")))
+
+	// Phase two -- the old expectations should no longer apply.
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 119)("a").
+		WillOnce(Return(19))
+
+	rets = t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"a"})
+
+	ExpectThat(rets, ElementsAre(19))
+
+	t.controller.HandleMethodCall(
+		t.mock2,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"b"})
+
+	AssertEq(2, len(t.reporter.errors))
+	ExpectEq("taco.go", t.reporter.errors[1].fileName)
+	ExpectThat(t.reporter.errors[1].err, Error(HasSubstr("Unexpected")))
+
+	// Finish should find nothing else wrong.
+	t.controller.Finish()
+
+	ExpectEq(2, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) CheckpointPreservesDefaults() {
+	t.controller.SetMode(t.mock1, Nice)
+	t.controller.OnCall(t.mock1, "StringToInt", "", 0)(Any()).
+		WillByDefault(Return(17))
+
+	t.controller.Checkpoint()
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"a"})
+
+	ExpectThat(rets, ElementsAre(17))
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(1, len(t.controller.Calls()))
+}
+
+func (t *ControllerTest) CheckpointDiscardsCalls() {
+	t.controller.SetDefaultMode(Nice)
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"a"})
+	t.controller.HandleMethodCall(t.mock2, "StringToInt", "", 0, []interface{}{"b"})
+
+	t.controller.Checkpoint()
+	ExpectEq(0, len(t.controller.Calls()))
+
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"c"})
+
+	calls := t.controller.Calls()
+	AssertEq(1, len(calls))
+	ExpectThat(calls[0].Args, ElementsAre("c"))
+}
+
+func (t *ControllerTest) CheckpointRemovesExpectationsFromSequences() {
+	seq := NewSequence()
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a").
+		InSequence(seq)
+
+	t.controller.Checkpoint()
+	AssertEq(1, len(t.reporter.errors))
+
+	// An expectation added to the sequence afterward shouldn't have to follow
+	// the discarded one.
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 118)("b").
+		WillOnce(Return(17)).
+		InSequence(seq)
+
+	rets := t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"b"})
+
+	ExpectThat(rets, ElementsAre(17))
+
+	t.controller.Finish()
+	ExpectEq(1, len(t.reporter.errors), "%v", t.reporter.errors)
+}
+
+func (t *ControllerTest) VerifyObjectChecksOnlyThatObject() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a")
+	t.controller.ExpectCall(t.mock2, "StringToInt", "burrito.go", 118)("b")
+
+	t.controller.VerifyObject(t.mock2)
+
+	AssertEq(1, len(t.reporter.errors))
+	AssertEq(0, len(t.reporter.fatalErrors))
+
+	ExpectEq("burrito.go", t.reporter.errors[0].fileName)
+	ExpectEq(118, t.reporter.errors[0].lineNumber)
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unsatisfied")))
+
+	// The expectations should still be in place.
+	t.controller.HandleMethodCall(
+		t.mock2,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"b"})
+
+	t.controller.VerifyObject(t.mock2)
+	ExpectEq(1, len(t.reporter.errors))
+}
+
+func (t *ControllerTest) VerifyObjectWithNoExpectations() {
+	t.controller.VerifyObject(t.mock1)
+
+	ExpectEq(0, len(t.reporter.errors))
+	ExpectEq(0, len(t.reporter.fatalErrors))
+}
+
+func (t *ControllerTest) ResetObjectDiscardsOnlyThatObject() {
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 117)("a")
+	t.controller.ExpectCall(t.mock2, "StringToInt", "burrito.go", 118)("b")
+
+	t.controller.ResetObject(t.mock1)
+
+	// Calls to the reset object should now be unexpected.
+	t.controller.HandleMethodCall(
+		t.mock1,
+		"StringToInt",
+		"taco.go",
+		112,
+		[]interface{}{"a"})
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectEq("taco.go", t.reporter.errors[0].fileName)
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unexpected")))
+
+	// The other object's expectations should be untouched.
+	t.controller.Finish()
+
+	AssertEq(2, len(t.reporter.errors))
+	ExpectEq("burrito.go", t.reporter.errors[1].fileName)
+	ExpectEq(118, t.reporter.errors[1].lineNumber)
+	ExpectThat(t.reporter.errors[1].err, Error(HasSubstr("Unsatisfied")))
+}
+
+func (t *ControllerTest) ResetObjectDiscardsCallsForThatObject() {
+	t.controller.SetDefaultMode(Nice)
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"a"})
+	t.controller.HandleMethodCall(t.mock2, "StringToInt", "", 0, []interface{}{"b"})
+	t.controller.HandleMethodCall(t.mock1, "StringToInt", "", 0, []interface{}{"c"})
+
+	t.controller.ResetObject(t.mock1)
+
+	calls := t.controller.Calls()
+	AssertEq(1, len(calls))
+	ExpectEq(t.mock2, calls[0].Object)
+	ExpectThat(calls[0].Args, ElementsAre("b"))
+}
+
+func (t *ControllerTest) ResetObjectRemovesExpectationsFromSequences() {
+	seq := NewSequence()
+
+	t.controller.ExpectCall(t.mock2, "StringToInt", "burrito.go", 117)("a").
+		InSequence(seq)
+
+	t.controller.ExpectCall(t.mock1, "StringToInt", "burrito.go", 118)("b").
+		InSequence(seq)
+
+	t.controller.ExpectCall(t.mock2, "StringToInt", "burrito.go", 119)("c").
+		InSequence(seq)
+
+	t.controller.ResetObject(t.mock1)
+
+	// The last expectation should still follow the first, but not the one that
+	// was discarded.
+	t.controller.HandleMethodCall(t.mock2, "StringToInt", "taco.go", 112, []interface{}{"c"})
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Out of order")))
+
+	t.controller.HandleMethodCall(t.mock2, "StringToInt", "", 0, []interface{}{"a"})
+	t.controller.HandleMethodCall(t.mock2, "StringToInt", "", 0, []interface{}{"c"})
+
+	t.controller.Finish()
+	ExpectEq(1, len(t.reporter.errors), "%v", t.reporter.errors)
+}
+
+func (t *ControllerTest) ErrorsDescribeMockObjectAndSignature() {
+	const desc = `oglemock_test.trivialMockObject.StringToInt(string) int on "burrito"`
+
+	// Unexpected call.
+	t.controller.ExpectCall(t.mock2, "StringToInt", "burrito.go", 117)("a")
+
+	t.controller.HandleMethodCall(
+		t.mock2,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"b"})
+
+	// Over-saturation.
+	t.controller.HandleMethodCall(
+		t.mock2,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"a"})
+
+	t.controller.HandleMethodCall(
+		t.mock2,
+		"StringToInt",
+		"",
+		0,
+		[]interface{}{"a"})
+
+	// Unsatisfied at Finish.
+	t.controller.ExpectCall(t.mock2, "StringToInt", "burrito.go", 118)("c")
+	t.controller.Finish()
+
+	AssertEq(3, len(t.reporter.errors))
+	ExpectThat(t.reporter.errors[0].err, Error(HasSubstr("Unexpected call to "+desc)))
+	ExpectThat(t.reporter.errors[1].err, Error(HasSubstr("Unexpected call to "+desc)))
+	ExpectThat(t.reporter.errors[2].err, Error(HasSubstr("expected "+desc)))
+}
+
+func (t *ControllerTest) UnknownMethodDescribesMockObject() {
+	t.controller.ExpectCall(t.mock1, "Frobnicate", "burrito.go", 117)
+
+	AssertEq(1, len(t.reporter.fatalErrors))
+	ExpectThat(
+		t.reporter.fatalErrors[0].err,
+		Error(HasSubstr(`trivialMockObject.Frobnicate on "taco"`)))
+}
+
+func (t *ControllerTest) UnexportedMethod() {
+	o := &unexportedMethodMockObject{trivialMockObject{17, "enchilada"}}
+
+	t.controller.ExpectCall(o, "stringToInt", "burrito.go", 117)("a").
+		WillOnce(Return(19))
+
+	ret := t.controller.HandleMethodCall(o, "stringToInt", "", 0, []interface{}{"a"})
+	AssertThat(ret, ElementsAre(19))
+
+	// Calls with the wrong arguments are described in terms of the signature.
+	t.controller.HandleMethodCall(o, "stringToInt", "", 0, []interface{}{"b"})
+
+	AssertEq(1, len(t.reporter.errors))
+	ExpectThat(
+		t.reporter.errors[0].err,
+		Error(HasSubstr(`unexportedMethodMockObject.stringToInt(string) int on "enchilada"`)))
+
+	// Other unknown methods are still reported.
+	t.controller.ExpectCall(o, "frobnicate", "burrito.go", 118)
+	ExpectEq(1, len(t.reporter.fatalErrors))
+}
@@ -15,12 +15,42 @@
 
 // createmock is used to generate source code for mock versions of interfaces
 // from installed packages.
+//
+// The interfaces may be given as a package followed by the names of
+// interfaces within it:
+//
+//	createmock io Reader Writer
+//
+// or as any number of qualified names, from any number of packages, to be
+// mocked in a single file named after the first package:
+//
+//	createmock io.Reader net.Conn github.com/jacobsa/gcs.Bucket
+//
+// Mocks may be kept up to date with go generate by adding a directive like the
+// following to a file in the package that uses them:
+//
+//	//go:generate createmock -o mock_io/mock_io.go io Reader Writer
+//
+// With -o, the output file is only replaced once the mock source has been
+// generated successfully, so a failure doesn't leave it truncated.
+//
+// Adding --check to the same command checks that the file is up to date
+// without modifying it, e.g. in a continuous integration job.
+//
+// With --same_package, the output is suitable for a file within the package
+// containing the interfaces, such as a _test.go file. Unexported interfaces,
+// and interfaces with unexported methods or unexported types in their
+// signatures, may then be mocked too:
+//
+//	createmock --same_package -o mock_test.go ./foo tokenSource
 package main
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
+	"go/ast"
 	"go/build"
 	"io/ioutil"
 	"log"
@@ -28,23 +58,48 @@ import (
 	"os/exec"
 	"path"
 	"regexp"
+	"strings"
 	"text/template"
 
-	// Ensure that the generate package, which is used by the generated code, is
-	// installed by goinstall.
-	_ "github.com/jacobsa/oglemock/generate"
+	// The generate package is also used by the generated code, so this ensures
+	// that it is installed by goinstall.
+	"github.com/jacobsa/oglemock/generate"
 )
 
+var fSource = flag.Bool(
+	"source",
+	false,
+	"Load the package containing the interfaces from source with go/packages "+
+		"rather than building a helper binary. This doesn't require the "+
+		"package to be in $GOPATH, so it works in module mode.")
+
+var fDestination = flag.String(
+	"destination",
+	"",
+	"A file to which the output should be written, replacing it atomically, "+
+		"instead of printing it. The file starts with a header recording the "+
+		"command used to create it.")
+
+func init() {
+	flag.StringVar(fDestination, "o", "", "Shorthand for --destination.")
+}
+
+var fCheck = flag.Bool(
+	"check",
+	false,
+	"Instead of writing the file named by --destination, check that it is up "+
+		"to date. If it isn't, print a unified diff and exit with an error.")
+
 var fSamePackage = flag.Bool(
 	"same_package",
 	false,
 	"Generate output appropriate for including in the same package as the "+
-		"mocked interfaces.")
+		"mocked interfaces, such as a _test.go file. This allows unexported "+
+		"interfaces to be mocked. The package is loaded from source, as with "+
+		"--source.")
 
 // A template for generated code that is used to print the result.
 const tmplStr = `
-{{$interfacePkgPath := .InterfacePkgPath}}
-
 package main
 
 import (
@@ -62,8 +117,8 @@ func main() {
 	log.SetFlags(0)
 
 	interfaces := []reflect.Type{
-		{{range $typeName := .TypeNames}}
-			getTypeForPtr((*{{pathBase $interfacePkgPath}}.{{$typeName}})(nil)),
+		{{range .Interfaces}}
+			getTypeForPtr((*{{.Identifier}}.{{.Name}})(nil)),
 		{{end}}
 	}
 
@@ -82,18 +137,22 @@ func main() {
 // containing elements for each import needed by the generated code.
 type importMap map[string]string
 
-type tmplArg struct {
-	// The full path of the package from which the interfaces come.
-	InterfacePkgPath string
+// An interface to be mocked, referred to by the generated code as
+// Identifier.Name.
+type tmplInterface struct {
+	Identifier string
+	Name       string
+}
 
+type tmplArg struct {
 	// The package path to assume for the generated code.
 	OutputPkgPath string
 
 	// Imports needed by the generated code.
 	Imports importMap
 
-	// Types to be mocked, relative to their package's name.
-	TypeNames []string
+	// Types to be mocked.
+	Interfaces []tmplInterface
 }
 
 var unknownPackageRegexp = regexp.MustCompile(
@@ -123,6 +182,105 @@ func findUndefinedInterface(output []byte) *string {
 	return nil
 }
 
+// Split a qualified interface name such as "io.Reader" or
+// "github.com/foo/bar.Store[string, int]" into its package path and name,
+// returning false if it isn't qualified.
+func splitQualifiedName(s string) (pkgPath string, name string, ok bool) {
+	// Ignore any type arguments, which may themselves be qualified.
+	base := s
+	if i := strings.Index(s, "["); i >= 0 {
+		base = s[:i]
+	}
+
+	// The package path ends at the last dot after the last slash, if any. This
+	// allows for dots within the path's earlier elements, as in "github.com".
+	dot := strings.LastIndex(base, ".")
+	if dot <= 0 || dot <= strings.LastIndex(base, "/") || dot == len(base)-1 {
+		return
+	}
+
+	pkgPath = s[:dot]
+	name = s[dot+1:]
+	ok = true
+	return
+}
+
+// Interpret the command-line arguments as a list of interfaces grouped by
+// package, in order of first appearance. If every argument is a qualified
+// name, each names an interface; otherwise the first argument is a package and
+// the rest are names within it.
+func parseInterfaceArgs(
+	args []string) (pkgs []generate.PackageInterfaces, err error) {
+	qualified := len(args) > 0
+	for _, arg := range args {
+		if _, _, ok := splitQualifiedName(arg); !ok {
+			qualified = false
+		}
+	}
+
+	if !qualified {
+		if len(args) < 2 {
+			err = errors.New(
+				"Usage: createmock [package] [interface ...]\n" +
+					"       createmock [package.interface ...]")
+
+			return
+		}
+
+		pkgs = []generate.PackageInterfaces{
+			{PkgPath: args[0], Names: args[1:]},
+		}
+
+		return
+	}
+
+	indices := make(map[string]int)
+	for _, arg := range args {
+		pkgPath, name, _ := splitQualifiedName(arg)
+		i, ok := indices[pkgPath]
+		if !ok {
+			i = len(pkgs)
+			indices[pkgPath] = i
+			pkgs = append(pkgs, generate.PackageInterfaces{PkgPath: pkgPath})
+		}
+
+		pkgs[i].Names = append(pkgs[i].Names, name)
+	}
+
+	return
+}
+
+// Do any of the supplied interface names refer to unexported interfaces? The
+// helper binary can't refer to those, since it lives outside their packages.
+func hasUnexportedNames(pkgs []generate.PackageInterfaces) bool {
+	for _, p := range pkgs {
+		for _, name := range p.Names {
+			if i := strings.Index(name, "["); i >= 0 {
+				name = strings.TrimSpace(name[:i])
+			}
+
+			if !ast.IsExported(name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Is the go command operating in module mode with a main module, i.e. is
+// there a go.mod file in the current directory or one of its parents? If so,
+// packages are resolved according to that file rather than $GOPATH.
+func inModule() bool {
+	output, err := exec.Command("go", "env", "GOMOD").Output()
+	if err != nil {
+		return false
+	}
+
+	gomod := strings.TrimSpace(string(output))
+	return gomod != "" && gomod != os.DevNull
+}
+
 // Split out from main so that deferred calls are executed even in the event of
 // an error.
 func run() error {
@@ -132,9 +290,63 @@ func run() error {
 	// Check the command-line arguments.
 	flag.Parse()
 
-	cmdLineArgs := flag.Args()
-	if len(cmdLineArgs) < 2 {
-		return errors.New("Usage: createmock [package] [interface ...]")
+	pkgs, err := parseInterfaceArgs(flag.Args())
+	if err != nil {
+		return err
+	}
+
+	if *fCheck && *fDestination == "" {
+		return errors.New("--check requires --destination.")
+	}
+
+	// Unexported interfaces can only be implemented within their own package.
+	if hasUnexportedNames(pkgs) && !*fSamePackage {
+		return errors.New(
+			"Unexported interfaces can only be mocked with --same_package.")
+	}
+
+	// The output package is named after the first package.
+	outputPkgPath := "mock_" + path.Base(pkgs[0].PkgPath)
+
+	// In source mode, and for generic interfaces, everything is handled in
+	// process. The helper binary must live in $GOPATH, so source mode is
+	// always used within a module. It can't refer to anything unexported, and
+	// reflection doesn't record the name of the package containing the
+	// interfaces, so source mode is also used for same-package output and for
+	// interfaces with unexported methods or types in their methods (which are
+	// rejected unless the output is for the same package).
+	useSource := *fSource ||
+		*fSamePackage ||
+		inModule() ||
+		hasGenericInterfaces(pkgs) ||
+		refersToUnexported(pkgs)
+
+	if useSource {
+		interfaces, docs, err := generate.LoadPackageInterfaces("", pkgs)
+		if err != nil {
+			return err
+		}
+
+		// The package may have been given relative to the current directory,
+		// e.g. "." or "./foo", so use its real path.
+		pkgPath := interfaces[0].Obj().Pkg().Path()
+		outputPkgPath = "mock_" + path.Base(pkgPath)
+		if *fSamePackage {
+			outputPkgPath = pkgPath
+		}
+
+		buf := new(bytes.Buffer)
+		err = generate.GenerateMockSourceFromTypes(
+			buf,
+			outputPkgPath,
+			interfaces,
+			docs)
+
+		if err != nil {
+			return err
+		}
+
+		return writeOutput(buf.Bytes())
 	}
 
 	// Create a temporary directory inside of $GOPATH to hold generated code.
@@ -159,20 +371,24 @@ func run() error {
 	// Create an appropriate path for the built binary.
 	binaryPath := path.Join(tmpDir, "tool")
 
-	// Create an appropriate template argument.
+	// Create an appropriate template argument. Each package is imported with an
+	// identifier of its own, so that packages with the same name don't clash
+	// with each other or with the other imports.
 	arg := tmplArg{
-		InterfacePkgPath: cmdLineArgs[0],
-		TypeNames:        cmdLineArgs[1:],
+		OutputPkgPath: outputPkgPath,
+		Imports:       make(importMap),
 	}
 
-	if *fSamePackage {
-		arg.OutputPkgPath = arg.InterfacePkgPath
-	} else {
-		arg.OutputPkgPath = "mock_" + path.Base(arg.InterfacePkgPath)
+	for i, p := range pkgs {
+		identifier := fmt.Sprintf("pkg%d", i)
+		arg.Imports[identifier] = p.PkgPath
+		for _, name := range p.Names {
+			arg.Interfaces = append(
+				arg.Interfaces,
+				tmplInterface{Identifier: identifier, Name: name})
+		}
 	}
 
-	arg.Imports = make(importMap)
-	arg.Imports[path.Base(arg.InterfacePkgPath)] = arg.InterfacePkgPath
 	arg.Imports["generate"] = "github.com/jacobsa/oglemock/generate"
 	arg.Imports["log"] = "log"
 	arg.Imports["os"] = "os"
@@ -180,11 +396,7 @@ func run() error {
 
 	// Execute the template to generate code that will itself generate the mock
 	// code. Write the code to the temp file.
-	tmpl := template.Must(
-		template.New("code").Funcs(
-			template.FuncMap{
-				"pathBase": path.Base,
-			}).Parse(tmplStr))
+	tmpl := template.Must(template.New("code").Parse(tmplStr))
 	if err := tmpl.Execute(codeFile, arg); err != nil {
 		return errors.New(fmt.Sprintf("Error executing template: %v", err))
 	}
@@ -199,8 +411,10 @@ func run() error {
 	if err != nil {
 		// Did the compilation fail due to the user-specified package not being found?
 		pkg := findUnknownPackage(buildOutput)
-		if pkg != nil && *pkg == arg.InterfacePkgPath {
-			return errors.New(fmt.Sprintf("Unknown package: %s", *pkg))
+		for _, p := range pkgs {
+			if pkg != nil && *pkg == p.PkgPath {
+				return errors.New(fmt.Sprintf("Unknown package: %s", *pkg))
+			}
 		}
 
 		// Did the compilation fail due to an unknown interface?
@@ -221,6 +435,14 @@ func run() error {
 	binaryOutput, err := cmd.CombinedOutput()
 
 	if err != nil {
+		// Did the generator reject the interfaces, e.g. because two of them
+		// have the same name?
+		const prefix = "Error generating mock source: "
+		if bytes.HasPrefix(binaryOutput, []byte(prefix)) {
+			msg := strings.TrimPrefix(string(binaryOutput), prefix)
+			return errors.New(strings.TrimSpace(msg))
+		}
+
 		return errors.New(fmt.Sprintf(
 			"%s\n\nError running generated code:\n\n"+
 				"    %v\n\n Please report this oglemock bug.",
@@ -229,12 +451,7 @@ func run() error {
 	}
 
 	// Copy its output.
-	_, err = os.Stdout.Write(binaryOutput)
-	if err != nil {
-		return errors.New(fmt.Sprintf("Error copying binary output: %v", err))
-	}
-
-	return nil
+	return writeOutput(binaryOutput)
 }
 
 func main() {
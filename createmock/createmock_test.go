@@ -27,6 +27,7 @@ import (
 	"syscall"
 	"testing"
 
+	. "github.com/jacobsa/oglematchers"
 	. "github.com/jacobsa/ogletest"
 )
 
@@ -140,6 +141,36 @@ func (t *CreateMockTest) runCompilationTest(createmockArgs ...string) {
 	ExpectEq(nil, err, "go build output:\n\n%s", output)
 }
 
+// Create a temporary directory containing the supplied files, keyed by path
+// relative to the directory. The caller must delete the directory.
+func makeTreeOrDie(files map[string]string) string {
+	dir, err := ioutil.TempDir("", "createmock_test-")
+	if err != nil {
+		panic("ioutil.TempDir: " + err.Error())
+	}
+
+	for name, contents := range files {
+		p := path.Join(dir, name)
+		if err := os.MkdirAll(path.Dir(p), 0700); err != nil {
+			panic("os.MkdirAll: " + err.Error())
+		}
+
+		writeContentsToFileOrDie([]byte(contents), p)
+	}
+
+	return dir
+}
+
+// Run createmock in module mode within the supplied directory, returning its
+// combined output.
+func runInModule(dir string, createmockArgs ...string) ([]byte, error) {
+	cmd := exec.Command(createmockPath, createmockArgs...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=-mod=mod")
+
+	return cmd.CombinedOutput()
+}
+
 func writeContentsToFileOrDie(contents []byte, path string) {
 	if err := ioutil.WriteFile(path, contents, 0600); err != nil {
 		panic("ioutil.WriteFile: " + err.Error())
@@ -231,3 +262,431 @@ func (t *CreateMockTest) RenamedSamplePackage() {
 		"github.com/jacobsa/oglemock/generate/testdata/renamed_pkg",
 		"SomeInterface")
 }
+
+func (t *CreateMockTest) GenericInterface() {
+	t.runCompilationTest(
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		"Store",
+		"Source")
+}
+
+func (t *CreateMockTest) GenericInterfaceInstantiation() {
+	t.runCompilationTest(
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		"Store[string, int]")
+}
+
+func (t *CreateMockTest) MultiplePackages() {
+	t.runCompilationTest(
+		"io.Reader",
+		"image.Image",
+		"github.com/jacobsa/oglemock/generate/testdata/renamed_pkg.SomeInterface",
+		"io.Writer")
+}
+
+func (t *CreateMockTest) MultiplePackages_SameName() {
+	// Both packages are named v1, and other_fmt is named fmt.
+	t.runCompilationTest(
+		"github.com/jacobsa/oglemock/generate/testdata/versioned/a/v1.Getter",
+		"github.com/jacobsa/oglemock/generate/testdata/versioned/b/v1.Setter",
+		"github.com/jacobsa/oglemock/generate/testdata/other_fmt.Printer")
+}
+
+func (t *CreateMockTest) MultiplePackages_DuplicateName() {
+	t.runGoldenTest(
+		"duplicate_name",
+		1,
+		"image.Image",
+		"image/draw.Image")
+}
+
+func (t *CreateMockTest) ParseInterfaceArgs_PackageAndNames() {
+	pkgs, err := parseInterfaceArgs(
+		[]string{"gopkg.in/yaml.v2", "Marshaler", "Unmarshaler"})
+
+	AssertEq(nil, err)
+	AssertEq(1, len(pkgs))
+	ExpectEq("gopkg.in/yaml.v2", pkgs[0].PkgPath)
+	ExpectThat(pkgs[0].Names, ElementsAre("Marshaler", "Unmarshaler"))
+}
+
+func (t *CreateMockTest) ParseInterfaceArgs_QualifiedNames() {
+	pkgs, err := parseInterfaceArgs([]string{
+		"io.Reader",
+		"github.com/foo/bar.Store[string, bar.Thing]",
+		"./baz.Frobnicator",
+		"io.Writer",
+	})
+
+	AssertEq(nil, err)
+	AssertEq(3, len(pkgs))
+
+	ExpectEq("io", pkgs[0].PkgPath)
+	ExpectThat(pkgs[0].Names, ElementsAre("Reader", "Writer"))
+
+	ExpectEq("github.com/foo/bar", pkgs[1].PkgPath)
+	ExpectThat(pkgs[1].Names, ElementsAre("Store[string, bar.Thing]"))
+
+	ExpectEq("./baz", pkgs[2].PkgPath)
+	ExpectThat(pkgs[2].Names, ElementsAre("Frobnicator"))
+}
+
+func (t *CreateMockTest) SourceMode_UnknownPackage() {
+	t.runGoldenTest(
+		"unknown_package",
+		1,
+		"--source",
+		"foo/bar",
+		"Reader")
+}
+
+func (t *CreateMockTest) SourceMode_UnknownInterface() {
+	t.runGoldenTest(
+		"unknown_interface",
+		1,
+		"--source",
+		"io",
+		"Frobnicator")
+}
+
+func (t *CreateMockTest) SourceMode_GCSBucket() {
+	t.runGoldenTest(
+		"gcs_bucket_source",
+		0,
+		"--source",
+		"github.com/jacobsa/oglemock/createmock/testdata/gcs",
+		"Bucket")
+}
+
+func (t *CreateMockTest) SourceMode_IoReaderAndWriter() {
+	t.runCompilationTest(
+		"--source",
+		"io",
+		"Reader",
+		"Writer")
+}
+
+func (t *CreateMockTest) SourceMode_ComplicatedSamplePackage() {
+	t.runCompilationTest(
+		"--source",
+		"github.com/jacobsa/oglemock/generate/testdata/complicated_pkg",
+		"ComplicatedThing")
+}
+
+func (t *CreateMockTest) SourceMode_MultiplePackages() {
+	t.runCompilationTest(
+		"--source",
+		"io.Reader",
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg.Store",
+		"github.com/jacobsa/oglemock/createmock/testdata/gcs.Bucket")
+}
+
+func (t *CreateMockTest) SourceMode_MultiplePackages_SameName() {
+	t.runCompilationTest(
+		"--source",
+		"github.com/jacobsa/oglemock/generate/testdata/versioned/a/v1.Getter",
+		"github.com/jacobsa/oglemock/generate/testdata/versioned/b/v1.Setter",
+		"github.com/jacobsa/oglemock/generate/testdata/other_fmt.Printer")
+}
+
+func (t *CreateMockTest) SourceMode_DuplicateName() {
+	t.runGoldenTest(
+		"duplicate_name",
+		1,
+		"--source",
+		"image.Image",
+		"image/draw.Image")
+}
+
+func (t *CreateMockTest) UnexportedInterface_NotSamePackage() {
+	t.runGoldenTest(
+		"unexported_interface",
+		1,
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		"tokenSource")
+}
+
+func (t *CreateMockTest) UnexportedMethod_NotSamePackage() {
+	t.runGoldenTest(
+		"unexported_method",
+		1,
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		"Clock")
+}
+
+func (t *CreateMockTest) UnexportedType_NotSamePackage() {
+	t.runGoldenTest(
+		"unexported_type",
+		1,
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		"TokenGetter")
+}
+
+func (t *CreateMockTest) UnexportedMethod_SamePackage() {
+	cmd := exec.Command(
+		createmockPath,
+		"--same_package",
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		"Clock")
+
+	output, err := cmd.CombinedOutput()
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+
+	// The package's name differs from the last element of its path.
+	ExpectThat(string(output), HasSubstr("package hidden\n"))
+	ExpectThat(string(output), HasSubstr("func (m *mockClock) sleep(d time.Duration)"))
+	ExpectThat(string(output), HasSubstr("Oglemock_MethodSignature"))
+}
+
+func (t *CreateMockTest) SamePackage_UnexportedInterfaces() {
+	// Create a package inside of $GOPATH containing unexported interfaces and
+	// types.
+	buildPkg, err := build.Import("github.com/jacobsa/oglemock", "", build.FindOnly)
+	AssertEq(nil, err)
+
+	tmpDir, err := ioutil.TempDir(buildPkg.SrcRoot, "tmp-createmock_test-")
+	AssertEq(nil, err)
+	defer os.RemoveAll(tmpDir)
+
+	writeContentsToFileOrDie(
+		[]byte("package hidden\n\n"+
+			"type token struct {\n\tvalue string\n}\n\n"+
+			"type tokenSource interface {\n"+
+			"\tnext() (*token, error)\n"+
+			"\tName() string\n"+
+			"}\n"),
+		path.Join(tmpDir, "hidden.go"))
+
+	// Generate mocks into a test file within it.
+	cmd := exec.Command(
+		createmockPath,
+		"--same_package",
+		"-o", path.Join(tmpDir, "mock_test.go"),
+		path.Base(tmpDir),
+		"tokenSource")
+
+	cmd.Dir = buildPkg.SrcRoot
+	output, err := cmd.CombinedOutput()
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+
+	// A test using the mock should pass.
+	writeContentsToFileOrDie(
+		[]byte("package hidden\n\n"+
+			"import (\n"+
+			"\t\"testing\"\n\n"+
+			"\t\"github.com/jacobsa/oglemock\"\n"+
+			")\n\n"+
+			"func TestNext(t *testing.T) {\n"+
+			"\tc := oglemock.NewControllerForTest(t)\n"+
+			"\tm := newMockTokenSource(c, \"source\")\n"+
+			"\ttok := &token{\"taco\"}\n"+
+			"\tm.EXPECT().next().WillOnce(tokenSourceNextReturns(tok, nil))\n\n"+
+			"\tvar s tokenSource = m\n"+
+			"\tif got, err := s.next(); got != tok || err != nil {\n"+
+			"\t\tt.Errorf(\"next: %v, %v\", got, err)\n"+
+			"\t}\n\n"+
+			"\tm.EXPECT().Name().WillOnce(oglemock.Return(\"taco\"))\n"+
+			"\tif got := s.Name(); got != \"taco\" {\n"+
+			"\t\tt.Errorf(\"Name: %v\", got)\n"+
+			"\t}\n"+
+			"}\n"),
+		path.Join(tmpDir, "hidden_test.go"))
+
+	cmd = exec.Command("go", "test")
+	cmd.Dir = tmpDir
+	output, err = cmd.CombinedOutput()
+
+	ExpectEq(nil, err, "go test output:\n\n%s", output)
+}
+
+func (t *CreateMockTest) ModuleMode() {
+	dir := makeTreeOrDie(map[string]string{
+		"widgets/go.mod": "module example.com/widgets\n\n" +
+			"go 1.18\n\n" +
+			"require example.com/gadgets v0.0.0\n\n" +
+			"replace example.com/gadgets => ../gadgets\n",
+
+		"widgets/widget/widget.go": "package widget\n\n" +
+			"type Widget interface {\n\tFrob(n int) error\n}\n",
+
+		"gadgets/go.mod": "module example.com/gadgets\n\ngo 1.18\n",
+
+		"gadgets/gadget.go": "package gadget\n\n" +
+			"type Gadget interface {\n\tTwiddle() string\n}\n",
+	})
+
+	defer os.RemoveAll(dir)
+
+	// A package within the module.
+	output, err := runInModule(
+		path.Join(dir, "widgets"),
+		"example.com/widgets/widget",
+		"Widget")
+
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+	ExpectThat(string(output), HasSubstr("package mock_widget"))
+	ExpectThat(string(output), HasSubstr(`widget "example.com/widgets/widget"`))
+	ExpectThat(string(output), HasSubstr("type MockWidget interface"))
+
+	// The same package, given relative to the current directory.
+	output, err = runInModule(
+		path.Join(dir, "widgets"),
+		"--same_package",
+		"./widget",
+		"Widget")
+
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+	ExpectThat(string(output), HasSubstr("package widget"))
+	ExpectThat(string(output), Not(HasSubstr(`"example.com/widgets/widget"`)))
+
+	// The package in the current directory, as in a go:generate directive.
+	output, err = runInModule(
+		path.Join(dir, "widgets", "widget"),
+		".",
+		"Widget")
+
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+	ExpectThat(string(output), HasSubstr("package mock_widget"))
+	ExpectThat(string(output), HasSubstr(`widget "example.com/widgets/widget"`))
+	ExpectThat(string(output), HasSubstr("type MockWidget interface"))
+
+	// A package from another module, found through a replace directive.
+	output, err = runInModule(
+		path.Join(dir, "widgets"),
+		"example.com/gadgets",
+		"Gadget")
+
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+	ExpectThat(string(output), HasSubstr("package mock_gadgets"))
+	ExpectThat(string(output), HasSubstr(`gadget "example.com/gadgets"`))
+	ExpectThat(string(output), HasSubstr("type MockGadget interface"))
+}
+
+func (t *CreateMockTest) Destination() {
+	dir := makeTreeOrDie(nil)
+	defer os.RemoveAll(dir)
+
+	dest := path.Join(dir, "mock_generic_pkg", "mock_generic_pkg.go")
+
+	cmd := exec.Command(
+		createmockPath,
+		"-o", dest,
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		"Store[string, int]")
+
+	output, err := cmd.CombinedOutput()
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+	ExpectEq("", string(output))
+
+	// The file should start with a header recording the command, followed by
+	// the mock source.
+	contents := string(readFileOrDie(dest))
+	ExpectThat(
+		contents,
+		HasSubstr("// Code generated by createmock. DO NOT EDIT.\n"))
+
+	ExpectThat(
+		contents,
+		HasSubstr("//     createmock -o "+dest+" "+
+			"github.com/jacobsa/oglemock/generate/testdata/generic_pkg "+
+			"'Store[string, int]'\n"))
+
+	ExpectThat(contents, HasSubstr("package mock_generic_pkg"))
+	ExpectThat(contents, HasSubstr("type MockStore interface"))
+
+	// No temporary files should be left behind.
+	entries, err := ioutil.ReadDir(path.Dir(dest))
+	AssertEq(nil, err)
+	ExpectEq(1, len(entries))
+}
+
+func (t *CreateMockTest) Destination_FailureLeavesFileAlone() {
+	dir := makeTreeOrDie(map[string]string{
+		"mock.go": "taco",
+	})
+
+	defer os.RemoveAll(dir)
+
+	dest := path.Join(dir, "mock.go")
+
+	cmd := exec.Command(
+		createmockPath,
+		"--source",
+		"--destination", dest,
+		"io",
+		"Frobnicator")
+
+	output, err := cmd.CombinedOutput()
+	AssertNe(nil, err)
+	ExpectThat(string(output), HasSubstr("Unknown interface: Frobnicator"))
+
+	ExpectEq("taco", string(readFileOrDie(dest)))
+}
+
+func (t *CreateMockTest) Check() {
+	dir := makeTreeOrDie(nil)
+	defer os.RemoveAll(dir)
+
+	dest := path.Join(dir, "mock.go")
+	args := []string{
+		"-o", dest,
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		"Store[string, int]",
+	}
+
+	// Write the file.
+	output, err := exec.Command(createmockPath, args...).CombinedOutput()
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+
+	// Checking it should succeed silently.
+	checkArgs := append([]string{"--check"}, args...)
+	output, err = exec.Command(createmockPath, checkArgs...).CombinedOutput()
+	AssertEq(nil, err, "createmock output:\n\n%s", output)
+	ExpectEq("", string(output))
+
+	// Modify the file. Checking should now fail with a diff, leaving the file
+	// alone.
+	contents := readFileOrDie(dest)
+	stale := bytes.Replace(
+		contents,
+		[]byte("o1 bool) oglemock.Action"),
+		[]byte("o1 string) oglemock.Action"),
+		1)
+	writeContentsToFileOrDie(stale, dest)
+
+	output, err = exec.Command(createmockPath, checkArgs...).CombinedOutput()
+	AssertNe(nil, err)
+	ExpectThat(string(output), HasSubstr("--- "+dest+"\n"))
+	ExpectThat(string(output), HasSubstr("+++ "+dest+" (regenerated)\n"))
+	ExpectThat(string(output), HasSubstr("\n-func StoreGetReturns(o0 int, o1 string)"))
+	ExpectThat(string(output), HasSubstr("\n+func StoreGetReturns(o0 int, o1 bool)"))
+	ExpectThat(string(output), HasSubstr(dest+" is out of date."))
+
+	ExpectEq(string(stale), string(readFileOrDie(dest)))
+}
+
+func (t *CreateMockTest) Check_MissingFile() {
+	dir := makeTreeOrDie(nil)
+	defer os.RemoveAll(dir)
+
+	output, err := exec.Command(
+		createmockPath,
+		"--check",
+		"-o", path.Join(dir, "mock.go"),
+		"io",
+		"Reader").CombinedOutput()
+
+	AssertNe(nil, err)
+	ExpectThat(string(output), HasSubstr("Error reading"))
+}
+
+func (t *CreateMockTest) Check_NoDestination() {
+	output, err := exec.Command(
+		createmockPath,
+		"--check",
+		"io",
+		"Reader").CombinedOutput()
+
+	AssertNe(nil, err)
+	ExpectThat(string(output), HasSubstr("--check requires --destination"))
+}
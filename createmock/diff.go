@@ -0,0 +1,230 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// The number of unchanged lines shown around each change in a unified diff.
+const diffContext = 3
+
+// A single line of an edit script turning one list of lines into another.
+type diffEdit struct {
+	op   byte // ' ' for a line in both, '-' for a deletion, '+' for an insertion
+	line string
+}
+
+// Split text into lines, keeping line terminators so that a missing final
+// newline shows up as a difference.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// Compute a shortest edit script from a to b using Myers' algorithm.
+func diffLines(a, b []string) []diffEdit {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+
+	// v[k+offset] is the furthest x reached on diagonal k. trace records v
+	// before each round so that the path can be recovered. Round d reads only
+	// diagonals -d-1 through d+1, so only those are kept, with trace[d][0]
+	// being for diagonal -d-1.
+	v := make([]int, 2*max+3)
+	var trace [][]int
+
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+				x = v[k+1+offset]
+			} else {
+				x = v[k-1+offset] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[k+offset] = x
+			if x >= n && y >= m {
+				return backtrackDiff(a, b, trace)
+			}
+		}
+	}
+
+	panic("diffLines: no path found")
+}
+
+// Walk back through the trace recorded by diffLines to produce the edits.
+func backtrackDiff(a, b []string, trace [][]int) []diffEdit {
+	var edits []diffEdit
+	x, y := len(a), len(b)
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		offset := d + 1
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := v[prevK+offset]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, diffEdit{' ', a[x]})
+		}
+
+		if d > 0 {
+			if x == prevX {
+				y--
+				edits = append(edits, diffEdit{'+', b[y]})
+			} else {
+				x--
+				edits = append(edits, diffEdit{'-', a[x]})
+			}
+		}
+	}
+
+	// The edits were collected backward.
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+
+	return edits
+}
+
+// Return a unified diff turning a into b, with the supplied file names in the
+// header, or the empty string if they are the same.
+func unifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+
+	edits := diffLines(splitLines(a), splitLines(b))
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "--- %s\n+++ %s\n", aName, bName)
+
+	// Find each run of edits containing changes separated by no more than
+	// twice the context, and print it as a hunk.
+	for start := 0; start < len(edits); {
+		// Skip to the next change.
+		for start < len(edits) && edits[start].op == ' ' {
+			start++
+		}
+
+		if start == len(edits) {
+			break
+		}
+
+		// Extend the hunk until there is enough unchanged text to end it.
+		end := start
+		for i := start; i < len(edits); i++ {
+			if edits[i].op != ' ' {
+				end = i + 1
+				continue
+			}
+
+			if i-end >= 2*diffContext {
+				break
+			}
+		}
+
+		writeHunk(buf, edits, start, end)
+		start = end
+	}
+
+	return buf.String()
+}
+
+// Write out the hunk containing the changes in edits[start:end], along with
+// the surrounding context.
+func writeHunk(
+	buf *bytes.Buffer,
+	edits []diffEdit,
+	start int,
+	end int) {
+	first := start - diffContext
+	if first < 0 {
+		first = 0
+	}
+
+	last := end + diffContext
+	if last > len(edits) {
+		last = len(edits)
+	}
+
+	// Compute the line numbers at which the hunk starts in each file.
+	aLine, bLine := 1, 1
+	for _, e := range edits[:first] {
+		if e.op != '+' {
+			aLine++
+		}
+
+		if e.op != '-' {
+			bLine++
+		}
+	}
+
+	var aCount, bCount int
+	for _, e := range edits[first:last] {
+		if e.op != '+' {
+			aCount++
+		}
+
+		if e.op != '-' {
+			bCount++
+		}
+	}
+
+	// An empty range starts at the line before.
+	if aCount == 0 {
+		aLine--
+	}
+
+	if bCount == 0 {
+		bLine--
+	}
+
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+	for _, e := range edits[first:last] {
+		buf.WriteByte(e.op)
+		buf.WriteString(e.line)
+		if !strings.HasSuffix(e.line, "\n") {
+			buf.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
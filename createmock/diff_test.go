@@ -0,0 +1,165 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	. "github.com/jacobsa/ogletest"
+)
+
+////////////////////////////////////////////////////////////
+// Helpers
+////////////////////////////////////////////////////////////
+
+type DiffTest struct {
+}
+
+// Return the length of the longest common subsequence of a and b.
+func lcsLength(a, b []string) int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] > lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	return lengths[0][0]
+}
+
+// Return a random list of lines drawn from a small alphabet, so that lists
+// have plenty in common.
+func randomLines(r *rand.Rand) []string {
+	lines := make([]string, r.Intn(40))
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%d\n", r.Intn(5))
+	}
+
+	return lines
+}
+
+func init() { RegisterTestSuite(&DiffTest{}) }
+
+////////////////////////////////////////////////////////////
+// Tests
+////////////////////////////////////////////////////////////
+
+func (t *DiffTest) Identical() {
+	ExpectEq("", unifiedDiff("a", "b", "foo\nbar\n", "foo\nbar\n"))
+}
+
+func (t *DiffTest) SingleChange() {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n"
+	b := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n"
+
+	ExpectEq(
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -2,7 +2,7 @@\n"+
+			" 2\n"+
+			" 3\n"+
+			" 4\n"+
+			"-5\n"+
+			"+five\n"+
+			" 6\n"+
+			" 7\n"+
+			" 8\n",
+		unifiedDiff("a", "b", a, b))
+}
+
+func (t *DiffTest) SeparateHunks() {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+
+	ExpectEq(
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,4 +1,4 @@\n"+
+			"-1\n"+
+			"+one\n"+
+			" 2\n"+
+			" 3\n"+
+			" 4\n"+
+			"@@ -10,3 +10,4 @@\n"+
+			" 10\n"+
+			" 11\n"+
+			" 12\n"+
+			"+13\n",
+		unifiedDiff("a", "b", a, b))
+}
+
+func (t *DiffTest) EmptyFile() {
+	ExpectEq(
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -0,0 +1,2 @@\n"+
+			"+foo\n"+
+			"+bar\n",
+		unifiedDiff("a", "b", "", "foo\nbar\n"))
+}
+
+func (t *DiffTest) MissingFinalNewline() {
+	ExpectEq(
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,2 +1,2 @@\n"+
+			" foo\n"+
+			"-bar\n"+
+			"\\ No newline at end of file\n"+
+			"+bar\n",
+		unifiedDiff("a", "b", "foo\nbar", "foo\nbar\n"))
+}
+
+func (t *DiffTest) EditsAreShortestScript() {
+	r := rand.New(rand.NewSource(17))
+	for i := 0; i < 200; i++ {
+		a := randomLines(r)
+		b := randomLines(r)
+
+		var gotA, gotB []string
+		changes := 0
+		for _, e := range diffLines(a, b) {
+			if e.op != '+' {
+				gotA = append(gotA, e.line)
+			}
+
+			if e.op != '-' {
+				gotB = append(gotB, e.line)
+			}
+
+			if e.op != ' ' {
+				changes++
+			}
+		}
+
+		AssertEq(strings.Join(a, ""), strings.Join(gotA, ""), "a: %q, b: %q", a, b)
+		AssertEq(strings.Join(b, ""), strings.Join(gotB, ""), "a: %q, b: %q", a, b)
+		AssertEq(len(a)+len(b)-2*lcsLength(a, b), changes, "a: %q, b: %q", a, b)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var safeShellWordRegexp = regexp.MustCompile(`^[\pL0-9_@%+=:,./-]+$`)
+
+// Quote the supplied word for use in a shell command, if necessary.
+func shellQuote(s string) string {
+	if safeShellWordRegexp.MatchString(s) {
+		return s
+	}
+
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// Is the supplied command-line argument a use of the --check flag?
+func isCheckFlag(arg string) bool {
+	switch strings.TrimLeft(arg, "-") {
+	case "check", "check=true", "check=1":
+		return strings.HasPrefix(arg, "-")
+	}
+
+	return false
+}
+
+// Return a header for a file written with --destination, recording the
+// command that created it. Any --check flag is omitted, so that the header
+// doesn't depend on whether the file is being written or checked.
+func makeHeader(args []string) []byte {
+	words := []string{"createmock"}
+	for _, a := range args {
+		if isCheckFlag(a) {
+			continue
+		}
+
+		words = append(words, shellQuote(a))
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Code generated by createmock. DO NOT EDIT.\n")
+	fmt.Fprintf(buf, "//\n")
+	fmt.Fprintf(buf, "// To regenerate this file, run:\n")
+	fmt.Fprintf(buf, "//\n")
+	fmt.Fprintf(buf, "//     %s\n", strings.Join(words, " "))
+	fmt.Fprintf(buf, "\n")
+
+	return buf.Bytes()
+}
+
+// Write the generated source to the file named by --destination, or to stdout
+// if there is none.
+func writeOutput(source []byte) error {
+	if *fDestination == "" {
+		if _, err := os.Stdout.Write(source); err != nil {
+			return errors.New(fmt.Sprintf("Error copying output: %v", err))
+		}
+
+		return nil
+	}
+
+	contents := append(makeHeader(os.Args[1:]), source...)
+	if *fCheck {
+		return checkFile(*fDestination, contents)
+	}
+
+	if err := writeFileAtomically(*fDestination, contents); err != nil {
+		return errors.New(fmt.Sprintf("Error writing %s: %v", *fDestination, err))
+	}
+
+	return nil
+}
+
+// Check that the named file has the supplied contents. If not, print a
+// unified diff to stdout and return an error.
+func checkFile(name string, contents []byte) error {
+	existing, err := ioutil.ReadFile(name)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error reading %s: %v", name, err))
+	}
+
+	diff := unifiedDiff(
+		name,
+		name+" (regenerated)",
+		string(existing),
+		string(contents))
+
+	if diff == "" {
+		return nil
+	}
+
+	if _, err := os.Stdout.WriteString(diff); err != nil {
+		return errors.New(fmt.Sprintf("Error writing diff: %v", err))
+	}
+
+	return errors.New(fmt.Sprintf("%s is out of date.", name))
+}
+
+// Replace the contents of the named file, creating it and its parent
+// directories if necessary. Readers see either the old contents or the new,
+// never a partially written file.
+func writeFileAtomically(name string, contents []byte) (err error) {
+	dir := filepath.Dir(name)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	// Write to a temporary file in the same directory, so that it can be
+	// renamed over the destination.
+	f, err := ioutil.TempFile(dir, "."+filepath.Base(name)+".tmp-")
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = f.Write(contents); err != nil {
+		f.Close()
+		return
+	}
+
+	if err = f.Close(); err != nil {
+		return
+	}
+
+	// TempFile creates files that only the owner can read.
+	if err = os.Chmod(f.Name(), 0644); err != nil {
+		return
+	}
+
+	err = os.Rename(f.Name(), name)
+	return
+}
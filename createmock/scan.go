@@ -0,0 +1,202 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/jacobsa/oglemock/generate"
+)
+
+// Generic interfaces can't be represented by reflection, so they can't be
+// handled by the helper binary that createmock usually builds. Instead they
+// are loaded from source with go/types.
+//
+// Report whether any of the named types is a generic interface, or an
+// instantiation of one such as "Store[string, int]". To keep this cheap, the
+// packages are only parsed, not type-checked. If a package can't be found or
+// parsed, false is returned so that the helper binary reports the problem.
+func hasGenericInterfaces(pkgs []generate.PackageInterfaces) bool {
+	for _, p := range pkgs {
+		for _, name := range p.Names {
+			if strings.Contains(name, "[") {
+				return true
+			}
+		}
+
+		specs := parseTypeSpecs(p.PkgPath)
+		for _, name := range p.Names {
+			if ts := specs[name]; ts != nil && ts.TypeParams != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Interfaces with unexported methods, or whose methods refer to unexported
+// types, can only be implemented within their own package, which the helper
+// binary can't check, so they are loaded from source with go/types too.
+//
+// Report whether any of the named interfaces is of that kind, including by way
+// of interfaces it embeds from the same package. As for hasGenericInterfaces,
+// the packages are only parsed.
+func refersToUnexported(pkgs []generate.PackageInterfaces) bool {
+	for _, p := range pkgs {
+		specs := parseTypeSpecs(p.PkgPath)
+		visited := make(map[string]bool)
+		for _, name := range p.Names {
+			if interfaceRefersToUnexported(specs, name, visited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Does the named interface, among the supplied type specs, have an unexported
+// method or a method referring to an unexported type? Interfaces embedded from
+// other packages aren't looked into.
+func interfaceRefersToUnexported(
+	specs map[string]*ast.TypeSpec,
+	name string,
+	visited map[string]bool) bool {
+	if visited[name] {
+		return false
+	}
+
+	visited[name] = true
+
+	ts := specs[name]
+	if ts == nil {
+		return false
+	}
+
+	it, ok := ts.Type.(*ast.InterfaceType)
+	if !ok {
+		return false
+	}
+
+	// The interface's type parameters aren't types declared in the package.
+	typeParams := make(map[string]bool)
+	if ts.TypeParams != nil {
+		for _, field := range ts.TypeParams.List {
+			for _, n := range field.Names {
+				typeParams[n.Name] = true
+			}
+		}
+	}
+
+	for _, field := range it.Methods.List {
+		for _, n := range field.Names {
+			if !n.IsExported() {
+				return true
+			}
+		}
+
+		if len(field.Names) > 0 {
+			if exprRefersToUnexported(field.Type, typeParams) {
+				return true
+			}
+
+			continue
+		}
+
+		embedded, ok := field.Type.(*ast.Ident)
+		if ok && interfaceRefersToUnexported(specs, embedded.Name, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Does the supplied type expression refer to an unexported type declared in
+// the same package?
+func exprRefersToUnexported(
+	expr ast.Expr,
+	typeParams map[string]bool) (found bool) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			// Skip the names of parameters and struct fields.
+			found = found || exprRefersToUnexported(n.Type, typeParams)
+			return false
+
+		case *ast.SelectorExpr:
+			// A type from another package, which must be exported.
+			return false
+
+		case *ast.Ident:
+			if !n.IsExported() &&
+				n.Name != "_" &&
+				!typeParams[n.Name] &&
+				types.Universe.Lookup(n.Name) == nil {
+				found = true
+			}
+		}
+
+		return !found
+	})
+
+	return
+}
+
+// Parse the files of the package with the supplied import path, returning the
+// specs of the types declared at the top level keyed by name, or nil if the
+// package can't be found or parsed.
+func parseTypeSpecs(pkgPath string) map[string]*ast.TypeSpec {
+	buildPkg, err := build.Import(pkgPath, ".", 0)
+	if err != nil {
+		return nil
+	}
+
+	fset := token.NewFileSet()
+	specs := make(map[string]*ast.TypeSpec)
+	for _, name := range append(buildPkg.GoFiles, buildPkg.CgoFiles...) {
+		file, err := parser.ParseFile(
+			fset,
+			filepath.Join(buildPkg.Dir, name),
+			nil,
+			parser.SkipObjectResolution)
+
+		if err != nil {
+			return nil
+		}
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range genDecl.Specs {
+				ts := spec.(*ast.TypeSpec)
+				specs[ts.Name.Name] = ts
+			}
+		}
+	}
+
+	return specs
+}
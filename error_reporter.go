@@ -27,3 +27,26 @@ type ErrorReporter interface {
 	// that this method does not return.
 	ReportFatalError(fileName string, lineNumber int, err error)
 }
+
+// WarningReporter may optionally be implemented by an ErrorReporter in order
+// to receive reports of events that are suspicious but should not cause test
+// failures, such as uninteresting calls to a naggy mock object (see MockMode).
+// If the reporter does not implement this interface, warnings are written to
+// the standard logger instead.
+type WarningReporter interface {
+	// Report a warning. If known, fileName and lineNumber should contain
+	// information about where the event occurred.
+	ReportWarning(fileName string, lineNumber int, err error)
+}
+
+// HelperReporter may optionally be implemented by an ErrorReporter for a test
+// framework that, like the standard testing package, attributes each failure
+// to the first function on the stack not marked as a helper. The controller
+// calls the function returned by HelperFunc to mark its own methods, and
+// generated mock methods do the same (see HelperFunc), so that failures are
+// attributed to the test code that called them.
+type HelperReporter interface {
+	// Return a function that marks its caller as a helper, such as the Helper
+	// method of a testing.TB.
+	HelperFunc() func()
+}
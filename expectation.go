@@ -15,17 +15,22 @@
 
 package oglemock
 
+import (
+	"time"
+)
+
 // Expectation is an expectation for zero or more calls to a mock method with
 // particular arguments or sets of arguments.
 type Expectation interface {
 	// Times expresses that a matching method call should happen exactly N times.
-	// Times must not be called more than once, and must not be called after
-	// WillOnce or WillRepeatedly.
+	// Times must not be called more than once, must not be called after
+	// WillOnce or WillRepeatedly, and must not be combined with Cardinality.
 	//
 	// The full rules for the cardinality of an expectation are as follows:
 	//
-	//  1. If an explicit cardinality is set with Times(N), then anything other
-	//     than exactly N matching calls will cause a test failure.
+	//  1. If an explicit cardinality is set with Times(N) or Cardinality(c),
+	//     then a number of matching calls outside of that range will cause a
+	//     test failure.
 	//
 	//  2. Otherwise, if there are any one-time actions set up, then it is
 	//     expected there will be at least that many matching calls. If there is
@@ -39,6 +44,12 @@ type Expectation interface {
 	//
 	Times(n uint) Expectation
 
+	// Cardinality expresses that the number of matching method calls should be
+	// within the range described by c, for example AtLeast(3) or Between(1, 5).
+	// The same restrictions apply as for Times, and the two must not both be
+	// called.
+	Cardinality(c Cardinality) Expectation
+
 	// WillOnce configures a "one-time action". WillOnce can be called zero or
 	// more times, but must be called after any call to Times and before any call
 	// to WillRepeatedly.
@@ -56,4 +67,38 @@ type Expectation interface {
 	// called, the fallback action is implicitly an action that returns zero
 	// values for the method's return values.
 	WillRepeatedly(a Action) Expectation
+
+	// InSequence adds the expectation to the end of each of the supplied
+	// sequences. A call matching this expectation will then be reported as an
+	// out-of-order call unless the minimum cardinality of every expectation
+	// preceding this one in any of the sequences has already been satisfied,
+	// and no expectation following this one has yet been matched.
+	//
+	// InSequence may be called any number of times, and in any order relative
+	// to the other methods above.
+	InSequence(seqs ...*Sequence) Expectation
+
+	// RetiresOnSaturation causes the expectation to stop matching calls once it
+	// has been matched the maximum number of times allowed by its cardinality.
+	// Further calls will then be matched against the expectations registered
+	// before it, rather than being reported as over-calls. For example:
+	//
+	//     // By default, Read returns EOF.
+	//     controller.ExpectCall(r, "Read", "foo.go", 17)(Any())
+	//         .WillRepeatedly(Return(0, io.EOF))
+	//
+	//     // But the first call returns some data.
+	//     controller.ExpectCall(r, "Read", "foo.go", 20)(Any())
+	//         .WillOnce(Return(4, nil))
+	//         .RetiresOnSaturation()
+	//
+	// RetiresOnSaturation has no effect on an expectation whose cardinality has
+	// no upper bound.
+	RetiresOnSaturation() Expectation
+
+	// Wait blocks until the expectation has been matched at least as many times
+	// as its cardinality requires, or until the timeout expires. It returns true
+	// in the former case. This is useful when the calls are made by background
+	// goroutines.
+	Wait(timeout time.Duration) bool
 }
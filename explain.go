@@ -0,0 +1,139 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// A description of how well a particular expectation matched a call.
+type candidate struct {
+	exp *InternalExpectation
+
+	// The index of the expectation in the list registered for the method. Later
+	// expectations take precedence, so they are preferred when breaking ties.
+	index int
+
+	// One line of explanation per argument, and the number of arguments that
+	// didn't match.
+	argLines    []string
+	numMismatch int
+
+	// Whether the expectation has been retired, in which case it could not have
+	// matched regardless of the arguments.
+	retired bool
+}
+
+type candidatesByCloseness []candidate
+
+func (s candidatesByCloseness) Len() int      { return len(s) }
+func (s candidatesByCloseness) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s candidatesByCloseness) Less(i, j int) bool {
+	if s[i].retired != s[j].retired {
+		return !s[i].retired
+	}
+
+	if s[i].numMismatch != s[j].numMismatch {
+		return s[i].numMismatch < s[j].numMismatch
+	}
+
+	return s[i].index > s[j].index
+}
+
+// Check the supplied arguments against each of the expectation's matchers,
+// recording the outcome for each.
+func makeCandidate(
+	exp *InternalExpectation,
+	index int,
+	args []interface{}) (c candidate) {
+	c.exp = exp
+	c.index = index
+
+	exp.mutex.Lock()
+	c.retired = exp.Retired
+	exp.mutex.Unlock()
+
+	for i, matcher := range exp.ArgMatchers {
+		var arg interface{}
+		if i < len(args) {
+			arg = args[i]
+		}
+
+		err := matcher.Matches(arg)
+		if err == nil {
+			c.argLines = append(
+				c.argLines,
+				fmt.Sprintf("arg %d: matches %s", i, matcher.Description()))
+			continue
+		}
+
+		c.numMismatch++
+		line := fmt.Sprintf(
+			"arg %d: expected %s, but was %v",
+			i,
+			matcher.Description(),
+			arg)
+
+		if err.Error() != "" {
+			line += fmt.Sprintf(" (%v)", err)
+		}
+
+		c.argLines = append(c.argLines, line)
+	}
+
+	return
+}
+
+// explainUnexpectedCall returns a human-readable explanation of why a call
+// with the supplied arguments didn't match any of the supplied expectations,
+// which are those registered for the method in the order they were
+// registered. The closest candidates are listed first.
+func explainUnexpectedCall(
+	expectations []*InternalExpectation,
+	args []interface{}) string {
+	if len(expectations) == 0 {
+		return "There are no expectations registered for this method."
+	}
+
+	candidates := make(candidatesByCloseness, len(expectations))
+	for i, exp := range expectations {
+		candidates[i] = makeCandidate(exp, i, args)
+	}
+
+	sort.Stable(candidates)
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(
+		buf,
+		"Tried %d expectation(s) for this method, closest first:",
+		len(candidates))
+
+	for _, c := range candidates {
+		fmt.Fprintf(buf, "\n\n  %s:%d:", c.exp.FileName, c.exp.LineNumber)
+		if c.retired {
+			buf.WriteString(" (retired)")
+		}
+
+		for _, line := range c.argLines {
+			fmt.Fprintf(buf, "\n    %s", line)
+		}
+	}
+
+	return buf.String()
+}
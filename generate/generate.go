@@ -30,7 +30,11 @@ import (
 	"path"
 	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 	"text/template"
+	"unicode"
+	"unicode/utf8"
 )
 
 const gTmplStr = `
@@ -40,7 +44,7 @@ const gTmplStr = `
 //     https://github.com/jacobsa/oglemock
 //
 
-package {{pathBase .OutputPkgPath}}
+package {{.OutputPkgName}}
 
 import (
 	{{range $identifier, $import := .Imports}}{{$identifier}} "{{$import}}"
@@ -48,42 +52,59 @@ import (
 )
 
 {{range .Interfaces}}
-	{{$interfaceName := printf "Mock%s" .Name}}
-	{{$structName := printf "mock%s" .Name}}
+	{{$interfaceName := .MockName}}
+	{{$structName := .StructName}}
+	{{$typeParams := .TypeParams}}
+	{{$typeArgs := .TypeArgs}}
 
-	type {{$interfaceName}} interface {
-		{{getTypeString .}}
+	{{$expecterName := .ExpecterName}}
+
+	type {{$interfaceName}}{{$typeParams}} interface {
+		{{.TypeString}}
 		oglemock.MockObject
+
+		// EXPECT returns a helper for setting up expectations on the mock object
+		// with compile-time checking of method names and argument counts.
+		EXPECT() *{{$expecterName}}{{$typeArgs}}
 	}
 
-	type {{$structName}} struct {
+	type {{$structName}}{{$typeParams}} struct {
 		controller oglemock.Controller
 		description string
 	}
 	
-	func New{{printf "Mock%s" .Name}}(
+	func {{.ConstructorName}}{{$typeParams}}(
 		c oglemock.Controller,
-		desc string) {{$interfaceName}} {
-	  return &{{$structName}}{
+		desc string,
+		opts ...oglemock.MockOption) {{$interfaceName}}{{$typeArgs}} {
+	  m := &{{$structName}}{{$typeArgs}}{
 			controller: c,
 			description: desc,
 		}
+
+		for _, opt := range opts {
+			opt(c, m)
+		}
+
+		return m
 	}
 	
-	func (m *{{$structName}}) Oglemock_Id() uintptr {
+	func (m *{{$structName}}{{$typeArgs}}) Oglemock_Id() uintptr {
 		return uintptr(unsafe.Pointer(m))
 	}
 	
-	func (m *{{$structName}}) Oglemock_Description() string {
+	func (m *{{$structName}}{{$typeArgs}}) Oglemock_Description() string {
 		return m.description
 	}
 
-	{{range getMethods .}}
-	  {{$funcType := .Type}}
-	  {{$inputTypes := getInputs $funcType}}
-	  {{$outputTypes := getOutputs $funcType}}
+	{{range .Methods}}
+	  {{$method := .}}
+
+		{{.Doc}}
+		func (m *{{$structName}}{{$typeArgs}}) {{.Name}}({{range $i, $type := .Inputs}}{{index $method.InputNames $i}} {{$type}}, {{end}}) ({{range $i, $type := .Outputs}}{{index $method.OutputNames $i}} {{$type}}, {{end}}) {
+			// Attribute any failures to the caller.
+			oglemock.HelperFunc(m.controller)()
 
-		func (m *{{$structName}}) {{.Name}}({{range $i, $type := $inputTypes}}p{{$i}} {{getInputTypeString $i $funcType}}, {{end}}) ({{range $i, $type := $outputTypes}}o{{$i}} {{getTypeString $type}}, {{end}}) {
 			// Get a file name and line number for the caller.
 			_, file, line, _ := runtime.Caller(1)
 
@@ -93,49 +114,299 @@ import (
 				"{{.Name}}",
 				file,
 				line,
-				[]interface{}{ {{range $i, $type := $inputTypes}}p{{$i}}, {{end}} })
+				[]interface{}{ {{range .InputNames}}{{.}}, {{end}} })
 
-			if len(retVals) != {{len $outputTypes}} {
+			if len(retVals) != {{len .Outputs}} {
 				panic(fmt.Sprintf("{{$structName}}.{{.Name}}: invalid return values: %v", retVals))
 			}
 
-			{{range $i, $type := $outputTypes}}
-				// o{{$i}} {{getTypeString $type}}
+			{{range $i, $type := .Outputs}}
+			  {{$name := index $method.OutputNames $i}}
+				// {{$name}} {{$type}}
 				if retVals[{{$i}}] != nil {
-					o{{$i}} = retVals[{{$i}}].({{getTypeString $type}})
+					{{$name}} = retVals[{{$i}}].({{$type}})
 				}
 			{{end}}
 
 			return
 		}
 	{{end}}
+
+	{{if .HasUnexportedMethods}}
+		func (m *{{$structName}}{{$typeArgs}}) Oglemock_MethodSignature(methodName string) interface{} {
+			switch methodName {
+			{{range .Methods}}
+				{{if not .Exported}}
+					case "{{.Name}}":
+						return ({{.FuncType}})(nil)
+				{{end}}
+			{{end}}
+			}
+
+			return nil
+		}
+	{{end}}
+
+	// {{$expecterName}} sets up expectations for {{$interfaceName}} objects. Each
+	// argument to its methods may be a value or an oglematchers.Matcher, as with
+	// oglemock.Controller.ExpectCall.
+	type {{$expecterName}}{{$typeParams}} struct {
+		m *{{$structName}}{{$typeArgs}}
+	}
+
+	func (m *{{$structName}}{{$typeArgs}}) EXPECT() *{{$expecterName}}{{$typeArgs}} {
+		return &{{$expecterName}}{{$typeArgs}}{m}
+	}
+
+	{{range .Methods}}
+	  {{$method := .}}
+
+		func (e *{{$expecterName}}{{$typeArgs}}) {{.Name}}({{range $i, $type := .Inputs}}{{index $method.InputNames $i}} {{getExpectedInputTypeString $i $method}}, {{end}}) oglemock.Expectation {
+			// Attribute any failures to the caller.
+			oglemock.HelperFunc(e.m.controller)()
+
+			// Get a file name and line number for the caller.
+			_, file, line, _ := runtime.Caller(1)
+
+			return e.m.controller.ExpectCall(
+				e.m,
+				"{{.Name}}",
+				file,
+				line)({{getExpectedArgsString .}})
+		}
+	{{end}}
+
+	{{$iface := .}}
+	{{range .Methods}}
+	  {{$method := .}}
+
+		// {{$iface.ReturnsName .}} returns an action for {{$interfaceName}}.{{.Name}}
+		// that returns the supplied values.
+		func {{$iface.ReturnsName .}}{{$typeParams}}({{range $i, $type := .Outputs}}{{index $method.OutputNames $i}} {{$type}}, {{end}}) oglemock.Action {
+			return oglemock.Return({{range .OutputNames}}{{.}}, {{end}})
+		}
+
+		// {{$iface.DoName .}} returns an action for {{$interfaceName}}.{{.Name}}
+		// that invokes the supplied function with the call's arguments and returns
+		// its results.
+		func {{$iface.DoName .}}{{$typeParams}}(f {{.FuncType}}) oglemock.Action {
+			return oglemock.Invoke(f)
+		}
+	{{end}}
 {{end}}
 `
 
 type tmplArg struct {
-	// The set of interfaces to mock, and the full name of the package from which
-	// they all come.
-	Interfaces       []reflect.Type
-	InterfacePkgPath string
+	// The set of interfaces to mock. They may come from different packages.
+	Interfaces []mockedInterface
 
-	// The package path for the generate code.
+	// The package path for the generate code, and the name of the package.
 	OutputPkgPath string
+	OutputPkgName string
 
 	// Imports needed by the interfaces.
 	Imports importMap
 }
 
-func (a *tmplArg) getInputTypeString(i int, ft reflect.Type) string {
-	numInputs := ft.NumIn()
-	if i == numInputs-1 && ft.IsVariadic() {
-		return "..." + a.getTypeString(ft.In(i).Elem())
+// A description of an interface to be mocked, in terms of strings suitable for
+// use in the output package. This insulates the template from the way in
+// which the interface was discovered.
+type mockedInterface struct {
+	// The interface's name, e.g. "Reader".
+	Name string
+
+	// The expression used to refer to the interface from the output package,
+	// e.g. "io.Reader".
+	TypeString string
+
+	// For a generic interface, its type parameter list as it should appear in a
+	// declaration (e.g. "[K comparable, V any]") and the corresponding list of
+	// type arguments (e.g. "[K, V]"). Both are empty for ordinary interfaces.
+	TypeParams string
+	TypeArgs   string
+
+	// The interface's methods, sorted by name.
+	Methods []mockedMethod
+}
+
+// The names of the generated types and constructor for the interface. An
+// unexported interface, which can only be mocked within its own package, gets
+// unexported names.
+func (i mockedInterface) MockName() string {
+	if !ast.IsExported(i.Name) {
+		return "mock" + exported(i.Name)
 	}
 
-	return a.getTypeString(ft.In(i))
+	return "Mock" + i.Name
 }
 
-func (a *tmplArg) getTypeString(t reflect.Type) string {
-	return typeString(t, a.OutputPkgPath)
+func (i mockedInterface) StructName() string {
+	if !ast.IsExported(i.Name) {
+		return "mock" + exported(i.Name) + "Impl"
+	}
+
+	return "mock" + i.Name
+}
+
+func (i mockedInterface) ConstructorName() string {
+	if !ast.IsExported(i.Name) {
+		return "newMock" + exported(i.Name)
+	}
+
+	return "NewMock" + i.Name
+}
+
+func (i mockedInterface) ExpecterName() string {
+	return i.MockName() + "Expecter"
+}
+
+// The names of the typed action constructors for the supplied method.
+func (i mockedInterface) ReturnsName(m mockedMethod) string {
+	return i.Name + exported(m.Name) + "Returns"
+}
+
+func (i mockedInterface) DoName(m mockedMethod) string {
+	return i.Name + exported(m.Name) + "Do"
+}
+
+// Return the names of all of the top-level declarations generated for the
+// interface.
+func (i mockedInterface) declaredNames() []string {
+	names := []string{
+		i.MockName(),
+		i.StructName(),
+		i.ConstructorName(),
+		i.ExpecterName(),
+	}
+
+	for _, m := range i.Methods {
+		names = append(names, i.ReturnsName(m), i.DoName(m))
+	}
+
+	return names
+}
+
+// Does the interface have any unexported methods? Reflection can't find them,
+// so the mock must report their signatures to the controller itself.
+func (i mockedInterface) HasUnexportedMethods() bool {
+	for _, m := range i.Methods {
+		if !m.Exported() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Return the supplied identifier with its first letter in upper case.
+func exported(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(r)) + name[size:]
+}
+
+// A description of a single method of an interface to be mocked.
+type mockedMethod struct {
+	Name string
+
+	// The types of the method's parameters and results. If the method is
+	// variadic, the final parameter's type has the form "...T".
+	Inputs  []string
+	Outputs []string
+
+	// The names to use for the method's parameters and results, in one to one
+	// correspondence with Inputs and Outputs.
+	InputNames  []string
+	OutputNames []string
+
+	// The method's doc comment, including comment markers, or the empty string
+	// if there is none.
+	Doc string
+
+	// Whether the method is variadic.
+	Variadic bool
+
+	// The method's signature as a function type, e.g. "func([]uint8) (int,
+	// error)".
+	FuncType string
+}
+
+func (m mockedMethod) Exported() bool {
+	return ast.IsExported(m.Name)
+}
+
+// Return the type of the parameter used for the i'th input of the supplied
+// method by generated expectation builders, which accept either a value or a
+// matcher.
+func getExpectedInputTypeString(i int, m mockedMethod) string {
+	if i == len(m.Inputs)-1 && m.Variadic {
+		return "...interface{}"
+	}
+
+	return "interface{}"
+}
+
+// Return the list of arguments with which a generated expectation builder for
+// the supplied method should call the partial expectation.
+func getExpectedArgsString(m mockedMethod) string {
+	numInputs := len(m.Inputs)
+	if numInputs == 0 {
+		return ""
+	}
+
+	args := m.InputNames
+	if !m.Variadic {
+		return strings.Join(args, ", ")
+	}
+
+	// The elements of the variadic slice must be passed along individually,
+	// so combine them with the other arguments into a single slice.
+	last := numInputs - 1
+	return fmt.Sprintf(
+		"append([]interface{}{%s}, %s...)...",
+		strings.Join(args[:last], ", "),
+		args[last])
+}
+
+// Describe the supplied interface type for the template, referring to types
+// as appropriate for the package with the given path and using the supplied
+// import identifiers, keyed by package path.
+func describeInterface(
+	it reflect.Type,
+	pkgPath string,
+	identifiers map[string]string) (desc mockedInterface) {
+	desc.Name = it.Name()
+	desc.TypeString = typeString(it, pkgPath, identifiers)
+
+	for _, m := range getMethods(it) {
+		ft := m.Type
+		md := mockedMethod{
+			Name:     m.Name,
+			Variadic: ft.IsVariadic(),
+			FuncType: typeString(ft, pkgPath, identifiers),
+		}
+
+		for i, t := range getInputs(ft) {
+			md.InputNames = append(md.InputNames, fmt.Sprintf("p%d", i))
+			if i == ft.NumIn()-1 && ft.IsVariadic() {
+				md.Inputs = append(
+					md.Inputs,
+					"..."+typeString(t.Elem(), pkgPath, identifiers))
+
+				continue
+			}
+
+			md.Inputs = append(md.Inputs, typeString(t, pkgPath, identifiers))
+		}
+
+		for i, t := range getOutputs(ft) {
+			md.OutputNames = append(md.OutputNames, fmt.Sprintf("o%d", i))
+			md.Outputs = append(md.Outputs, typeString(t, pkgPath, identifiers))
+		}
+
+		desc.Methods = append(desc.Methods, md)
+	}
+
+	return
 }
 
 func getMethods(it reflect.Type) []reflect.Method {
@@ -175,10 +446,97 @@ func getOutputs(ft reflect.Type) []reflect.Type {
 // containing elements for each import needed by a set of mocked interfaces.
 type importMap map[string]string
 
+// Return a map from package path to the identifier that the package is
+// imported with.
+func (m importMap) identifiers() map[string]string {
+	res := make(map[string]string)
+	for identifier, pkgPath := range m {
+		res[pkgPath] = identifier
+	}
+
+	return res
+}
+
+// Imports used by the generated code itself, which must keep their usual
+// identifiers.
+var builtinImports = importMap{
+	"fmt":      "fmt",
+	"oglemock": "github.com/jacobsa/oglemock",
+	"runtime":  "runtime",
+	"unsafe":   "unsafe",
+}
+
+// Identifiers declared locally by the generated code, which would hide any
+// imports with the same names.
+var templateLocals = map[string]bool{
+	"c":          true,
+	"desc":       true,
+	"e":          true,
+	"f":          true,
+	"file":       true,
+	"line":       true,
+	"m":          true,
+	"methodName": true,
+	"opt":        true,
+	"opts":       true,
+	"retVals":    true,
+}
+
+// Choose an import identifier for each package in the supplied map from
+// package path to package name, avoiding collisions with each other, with
+// the imports used by the generated code itself, and with its local
+// variables. A package keeps its name where possible; otherwise a numeric
+// suffix is added, e.g. "v1_2". The package with the supplied output path is
+// never imported.
+func assignImportIdentifiers(
+	pkgNames map[string]string,
+	outputPkgPath string) (imports importMap) {
+	imports = make(importMap)
+	for identifier, pkgPath := range builtinImports {
+		imports[identifier] = pkgPath
+	}
+
+	used := imports.identifiers()
+	used[outputPkgPath] = ""
+
+	// Handle packages in a predictable order, so that the output is stable.
+	var pkgPaths []string
+	for pkgPath := range pkgNames {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+
+	sort.Strings(pkgPaths)
+
+	for _, pkgPath := range pkgPaths {
+		if _, ok := used[pkgPath]; ok {
+			continue
+		}
+
+		name := pkgNames[pkgPath]
+		identifier := name
+		for n := 2; imports[identifier] != "" || templateLocals[identifier]; n++ {
+			identifier = fmt.Sprintf("%s_%d", name, n)
+		}
+
+		imports[identifier] = pkgPath
+		used[pkgPath] = identifier
+	}
+
+	// Remove any self-import.
+	for identifier, pkgPath := range imports {
+		if pkgPath == outputPkgPath {
+			delete(imports, identifier)
+		}
+	}
+
+	return
+}
+
 var typePackageIdentifierRegexp = regexp.MustCompile(`^([\pL_0-9]+)\.[\pL_0-9]+$`)
 
-// Add an import for the supplied type, without recursing.
-func addImportForType(imports importMap, t reflect.Type) {
+// Record the package needed for the supplied type in the given map from
+// package path to package name, without recursing.
+func addImportForType(pkgNames map[string]string, t reflect.Type) {
 	// If there is no package path, this is a built-in type and we don't need an
 	// import.
 	pkgPath := t.PkgPath()
@@ -196,50 +554,52 @@ func addImportForType(imports importMap, t reflect.Type) {
 	}
 
 	// Use the identifier that's part of the type's string representation as the
-	// import identifier. This means that we'll do the right thing for package
+	// package name. This means that we'll do the right thing for package
 	// "foo/bar" with declaration "package baz".
 	match := typePackageIdentifierRegexp.FindStringSubmatch(t.String())
 	if match == nil {
 		return
 	}
 
-	imports[match[1]] = pkgPath
+	pkgNames[pkgPath] = match[1]
 }
 
-// Add all necessary imports for the type, recursing as appropriate.
-func addImportsForType(imports importMap, t reflect.Type) {
+// Record all necessary packages for the type, recursing as appropriate.
+func addImportsForType(pkgNames map[string]string, t reflect.Type) {
 	// Add any import needed for the type itself.
-	addImportForType(imports, t)
+	addImportForType(pkgNames, t)
 
 	// Handle special cases where recursion is needed.
 	switch t.Kind() {
 	case reflect.Array, reflect.Chan, reflect.Ptr, reflect.Slice:
-		addImportsForType(imports, t.Elem())
+		addImportsForType(pkgNames, t.Elem())
 
 	case reflect.Func:
 		// Input parameters.
 		for i := 0; i < t.NumIn(); i++ {
-			addImportsForType(imports, t.In(i))
+			addImportsForType(pkgNames, t.In(i))
 		}
 
 		// Return values.
 		for i := 0; i < t.NumOut(); i++ {
-			addImportsForType(imports, t.Out(i))
+			addImportsForType(pkgNames, t.Out(i))
 		}
 
 	case reflect.Map:
-		addImportsForType(imports, t.Key())
-		addImportsForType(imports, t.Elem())
+		addImportsForType(pkgNames, t.Key())
+		addImportsForType(pkgNames, t.Elem())
 	}
 }
 
-// Add imports for each of the methods of the interface, but not the interface
-// itself.
-func addImportsForInterfaceMethods(imports importMap, it reflect.Type) {
+// Record packages for each of the methods of the interface, but not the
+// interface itself.
+func addImportsForInterfaceMethods(
+	pkgNames map[string]string,
+	it reflect.Type) {
 	// Handle each method.
 	for i := 0; i < it.NumMethod(); i++ {
 		m := it.Method(i)
-		addImportsForType(imports, m.Type)
+		addImportsForType(pkgNames, m.Type)
 	}
 }
 
@@ -249,32 +609,19 @@ func addImportsForInterfaceMethods(imports importMap, it reflect.Type) {
 func getImports(
 	interfaces []reflect.Type,
 	pkgPath string) importMap {
-	imports := make(importMap)
+	pkgNames := make(map[string]string)
 	for _, it := range interfaces {
-		addImportForType(imports, it)
-		addImportsForInterfaceMethods(imports, it)
+		addImportForType(pkgNames, it)
+		addImportsForInterfaceMethods(pkgNames, it)
 	}
 
-	// Make sure there are imports for other types used by the generated code
-	// itself.
-	imports["fmt"] = "fmt"
-	imports["oglemock"] = "github.com/jacobsa/oglemock"
-	imports["runtime"] = "runtime"
-	imports["unsafe"] = "unsafe"
-
-	// Remove any self-imports generated above.
-	for k, v := range imports {
-		if v == pkgPath {
-			delete(imports, k)
-		}
-	}
-
-	return imports
+	return assignImportIdentifiers(pkgNames, pkgPath)
 }
 
 // Given a set of interfaces to mock, write out source code suitable for
 // inclusion in a package with the supplied full package path containing mock
-// implementations of those interfaces.
+// implementations of those interfaces. The interfaces may come from different
+// packages, but their names must be distinct.
 func GenerateMockSource(
 	w io.Writer,
 	outputPkgPath string,
@@ -295,36 +642,58 @@ func GenerateMockSource(
 		}
 	}
 
-	// Make sure each interface is from the same package.
-	interfacePkgPath := interfaces[0].PkgPath()
-	for _, t := range interfaces {
-		if t.PkgPath() != interfacePkgPath {
-			err = fmt.Errorf(
-				"Package path mismatch: %q vs. %q",
-				interfacePkgPath,
-				t.PkgPath())
+	// Set up an appropriate template arg.
+	arg := tmplArg{
+		OutputPkgPath: outputPkgPath,
+		OutputPkgName: path.Base(outputPkgPath),
+		Imports:       getImports(interfaces, outputPkgPath),
+	}
 
-			return
-		}
+	identifiers := arg.Imports.identifiers()
+	for _, it := range interfaces {
+		arg.Interfaces = append(
+			arg.Interfaces,
+			describeInterface(it, outputPkgPath, identifiers))
 	}
 
-	// Set up an appropriate template arg.
-	arg := tmplArg{
-		Interfaces:       interfaces,
-		InterfacePkgPath: interfacePkgPath,
-		OutputPkgPath:    outputPkgPath,
-		Imports:          getImports(interfaces, outputPkgPath),
+	return writeMockSource(w, arg)
+}
+
+// Execute the template with the supplied argument, and write out the result
+// formatted in the same way that gofmt would.
+func writeMockSource(w io.Writer, arg tmplArg) (err error) {
+	// Interfaces from different packages may share a name, but their mocks
+	// can't. Names built from both interface and method names, such as that of
+	// ABCReturns for interfaces A and AB with methods BC and C, may clash too.
+	seen := make(map[string]int)
+	for i, it := range arg.Interfaces {
+		for _, name := range it.declaredNames() {
+			j, ok := seen[name]
+			switch {
+			case ok && j == i:
+				return fmt.Errorf(
+					"Interface %s would be mocked with two declarations of %s",
+					it.TypeString,
+					name)
+
+			case ok:
+				return fmt.Errorf(
+					"Interfaces %s and %s would both be mocked as %s",
+					arg.Interfaces[j].TypeString,
+					it.TypeString,
+					name)
+			}
+
+			seen[name] = i
+		}
 	}
 
 	// Configure and parse the template.
 	tmpl := template.New("code")
 	tmpl.Funcs(template.FuncMap{
-		"pathBase":           path.Base,
-		"getMethods":         getMethods,
-		"getInputs":          getInputs,
-		"getOutputs":         getOutputs,
-		"getInputTypeString": arg.getInputTypeString,
-		"getTypeString":      arg.getTypeString,
+		"exported":                   exported,
+		"getExpectedInputTypeString": getExpectedInputTypeString,
+		"getExpectedArgsString":      getExpectedArgsString,
 	})
 
 	_, err = tmpl.Parse(gTmplStr)
@@ -343,7 +712,7 @@ func GenerateMockSource(
 	fset := token.NewFileSet()
 	astFile, err := parser.ParseFile(
 		fset,
-		path.Base(outputPkgPath+".go"),
+		path.Base(arg.OutputPkgPath+".go"),
 		buf,
 		parser.ParseComments)
 
@@ -18,7 +18,10 @@ package generate_test
 import (
 	"bytes"
 	"flag"
+	"go/token"
+	"go/types"
 	"image"
+	"image/draw"
 	"io"
 	"io/ioutil"
 	"path"
@@ -27,8 +30,14 @@ import (
 
 	. "github.com/jacobsa/oglematchers"
 	"github.com/jacobsa/oglemock/generate"
+	"github.com/jacobsa/oglemock/generate/testdata/clashing_pkg"
 	"github.com/jacobsa/oglemock/generate/testdata/complicated_pkg"
+	"github.com/jacobsa/oglemock/generate/testdata/embedded_pkg"
+	"github.com/jacobsa/oglemock/generate/testdata/file"
+	otherfmt "github.com/jacobsa/oglemock/generate/testdata/other_fmt"
 	"github.com/jacobsa/oglemock/generate/testdata/renamed_pkg"
+	av1 "github.com/jacobsa/oglemock/generate/testdata/versioned/a/v1"
+	bv1 "github.com/jacobsa/oglemock/generate/testdata/versioned/b/v1"
 	. "github.com/jacobsa/ogletest"
 )
 
@@ -73,6 +82,53 @@ func (t *GenerateTest) runGoldenTest(
 	}
 }
 
+// Like runGoldenTest, but uses GenerateMockSourceFromTypes with the named types
+// loaded by LoadInterfaces from the package with the supplied path. Each type
+// may be an expression instantiating a generic type.
+func (t *GenerateTest) runTypesGoldenTest(
+	caseName string,
+	outputPkgPath string,
+	pkgPath string,
+	typeExprs ...string) {
+	t.runPackagesGoldenTest(
+		caseName,
+		outputPkgPath,
+		generate.PackageInterfaces{PkgPath: pkgPath, Names: typeExprs})
+}
+
+// Like runTypesGoldenTest, but with types from any number of packages.
+func (t *GenerateTest) runPackagesGoldenTest(
+	caseName string,
+	outputPkgPath string,
+	pkgs ...generate.PackageInterfaces) {
+	// Load the packages from source.
+	interfaces, docs, err := generate.LoadPackageInterfaces("", pkgs)
+	AssertEq(nil, err, "Error loading packages: %v", err)
+
+	// Create the mock source.
+	buf := new(bytes.Buffer)
+	err = generate.GenerateMockSourceFromTypes(
+		buf,
+		outputPkgPath,
+		interfaces,
+		docs)
+
+	AssertEq(nil, err, "Error from GenerateMockSourceFromTypes: %v", err)
+
+	// Read the golden file.
+	goldenPath := path.Join("testdata", "golden."+caseName+".go")
+	goldenData := readFileOrDie(goldenPath)
+
+	// Compare the two.
+	identical := (buf.String() == string(goldenData))
+	ExpectTrue(identical, "Output doesn't match for case '%s'.", caseName)
+
+	// Write out a new golden file if requested.
+	if !identical && *dumpNew {
+		writeContentsToFileOrDie(buf.Bytes(), goldenPath)
+	}
+}
+
 func writeContentsToFileOrDie(contents []byte, path string) {
 	if err := ioutil.WriteFile(path, contents, 0600); err != nil {
 		panic("ioutil.WriteFile: " + err.Error())
@@ -166,3 +222,322 @@ func (t *GenerateTest) RenamedPackage() {
 		"some/pkg",
 		(*tony.SomeInterface)(nil))
 }
+
+func (t *GenerateTest) EmbeddedInterfaces() {
+	t.runGoldenTest(
+		"embedded_pkg",
+		"some/pkg",
+		(*embedded_pkg.Widget)(nil),
+		(*embedded_pkg.File)(nil))
+}
+
+func (t *GenerateTest) EmbeddedInterfaces_FromTypes() {
+	t.runTypesGoldenTest(
+		"embedded_pkg_from_types",
+		"some/pkg",
+		"github.com/jacobsa/oglemock/generate/testdata/embedded_pkg",
+		"Widget",
+		"File")
+}
+
+func (t *GenerateTest) MultiplePackages() {
+	t.runGoldenTest(
+		"multiple_pkgs",
+		"some/pkg",
+		(*io.Reader)(nil),
+		(*image.Image)(nil),
+		(*tony.SomeInterface)(nil))
+}
+
+func (t *GenerateTest) MultiplePackages_FromTypes() {
+	t.runPackagesGoldenTest(
+		"multiple_pkgs_from_types",
+		"some/pkg",
+		generate.PackageInterfaces{
+			PkgPath: "io",
+			Names:   []string{"Reader"},
+		},
+		generate.PackageInterfaces{
+			PkgPath: "github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+			Names:   []string{"Store"},
+		})
+}
+
+func (t *GenerateTest) MultiplePackages_DuplicateName() {
+	err := generate.GenerateMockSource(
+		new(bytes.Buffer),
+		"some/pkg",
+		[]reflect.Type{
+			reflect.TypeOf((*image.Image)(nil)).Elem(),
+			reflect.TypeOf((*draw.Image)(nil)).Elem(),
+		})
+
+	ExpectThat(err, Error(HasSubstr("image.Image and draw.Image")))
+	ExpectThat(err, Error(HasSubstr("MockImage")))
+}
+
+func (t *GenerateTest) ClashingActionNames() {
+	err := generate.GenerateMockSource(
+		new(bytes.Buffer),
+		"some/pkg",
+		[]reflect.Type{
+			reflect.TypeOf((*clashing_pkg.A)(nil)).Elem(),
+			reflect.TypeOf((*clashing_pkg.AB)(nil)).Elem(),
+		})
+
+	ExpectThat(err, Error(HasSubstr("clashing_pkg.A and clashing_pkg.AB")))
+	ExpectThat(err, Error(HasSubstr("ABCReturns")))
+}
+
+func (t *GenerateTest) ConflictingPackageNames() {
+	t.runGoldenTest(
+		"conflicting_pkgs",
+		"some/pkg",
+		(*av1.Getter)(nil),
+		(*bv1.Setter)(nil),
+		(*otherfmt.Printer)(nil))
+}
+
+func (t *GenerateTest) ConflictingPackageNames_FromTypes() {
+	t.runPackagesGoldenTest(
+		"conflicting_pkgs_from_types",
+		"some/pkg",
+		generate.PackageInterfaces{
+			PkgPath: "github.com/jacobsa/oglemock/generate/testdata/versioned/a/v1",
+			Names:   []string{"Getter"},
+		},
+		generate.PackageInterfaces{
+			PkgPath: "github.com/jacobsa/oglemock/generate/testdata/versioned/b/v1",
+			Names:   []string{"Setter"},
+		},
+		generate.PackageInterfaces{
+			PkgPath: "github.com/jacobsa/oglemock/generate/testdata/other_fmt",
+			Names:   []string{"Printer"},
+		})
+}
+
+func (t *GenerateTest) PackageNamedLikeLocalVariable() {
+	t.runGoldenTest(
+		"file_pkg",
+		"some/pkg",
+		(*file.Statter)(nil))
+}
+
+func (t *GenerateTest) PackageNamedLikeLocalVariable_FromTypes() {
+	t.runPackagesGoldenTest(
+		"file_pkg_from_types",
+		"some/pkg",
+		generate.PackageInterfaces{
+			PkgPath: "github.com/jacobsa/oglemock/generate/testdata/file",
+			Names:   []string{"Statter"},
+		})
+}
+
+func (t *GenerateTest) UnexportedInterfaces_SamePackage() {
+	t.runTypesGoldenTest(
+		"unexported_pkg",
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		"tokenSource",
+		"Clock")
+}
+
+func (t *GenerateTest) UnexportedInterface_OtherPackage() {
+	interfaces, docs, err := generate.LoadInterfaces(
+		"",
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		[]string{"tokenSource"})
+
+	AssertEq(nil, err)
+
+	err = generate.GenerateMockSourceFromTypes(
+		new(bytes.Buffer),
+		"some/pkg",
+		interfaces,
+		docs)
+
+	ExpectThat(err, Error(HasSubstr("Unexported interface tokenSource")))
+}
+
+func (t *GenerateTest) UnexportedMethod_OtherPackage() {
+	interfaces, docs, err := generate.LoadInterfaces(
+		"",
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		[]string{"Clock"})
+
+	AssertEq(nil, err)
+
+	err = generate.GenerateMockSourceFromTypes(
+		new(bytes.Buffer),
+		"some/pkg",
+		interfaces,
+		docs)
+
+	ExpectThat(err, Error(HasSubstr("unexported method sleep")))
+}
+
+func (t *GenerateTest) UnexportedTypeInSignature_OtherPackage() {
+	interfaces, docs, err := generate.LoadInterfaces(
+		"",
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		[]string{"TokenGetter"})
+
+	AssertEq(nil, err)
+
+	err = generate.GenerateMockSourceFromTypes(
+		new(bytes.Buffer),
+		"some/pkg",
+		interfaces,
+		docs)
+
+	ExpectThat(err, Error(HasSubstr("Interface TokenGetter")))
+	ExpectThat(err, Error(HasSubstr("unexported type hidden.token")))
+}
+
+func (t *GenerateTest) UnexportedTypeInSignature_SamePackage() {
+	interfaces, docs, err := generate.LoadInterfaces(
+		"",
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		[]string{"TokenGetter"})
+
+	AssertEq(nil, err)
+
+	buf := new(bytes.Buffer)
+	err = generate.GenerateMockSourceFromTypes(
+		buf,
+		"github.com/jacobsa/oglemock/generate/testdata/unexported_pkg",
+		interfaces,
+		docs)
+
+	AssertEq(nil, err)
+	ExpectThat(buf.String(), HasSubstr("[]*token"))
+}
+
+func (t *GenerateTest) GenericInterfaces() {
+	t.runTypesGoldenTest(
+		"generic_pkg",
+		"some/pkg",
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		"Store",
+		"Source")
+}
+
+func (t *GenerateTest) GenericInterfaceInstantiation() {
+	t.runTypesGoldenTest(
+		"generic_pkg_instantiation",
+		"some/pkg",
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		"Store[string, int]")
+}
+
+func (t *GenerateTest) TypesNonInterfaceType() {
+	named := types.NewNamed(
+		types.NewTypeName(token.NoPos, types.NewPackage("foo", "foo"), "Bar", nil),
+		types.Typ[types.Int],
+		nil)
+
+	err := generate.GenerateMockSourceFromTypes(
+		new(bytes.Buffer),
+		"some/pkg",
+		[]*types.Named{named},
+		nil)
+
+	ExpectThat(err, Error(HasSubstr("Invalid type")))
+}
+
+func (t *GenerateTest) LoadInterfaces_UnknownPackage() {
+	_, _, err := generate.LoadInterfaces("", "foo/bar", []string{"Reader"})
+
+	e, ok := err.(*generate.UnknownPackageError)
+	AssertTrue(ok, "Unexpected error: %v", err)
+	ExpectEq("foo/bar", e.PkgPath)
+	ExpectTrue(e.Err != nil)
+}
+
+func (t *GenerateTest) LoadInterfaces_UnknownInterface() {
+	_, _, err := generate.LoadInterfaces("", "io", []string{"Reader", "Frobnicator"})
+
+	e, ok := err.(*generate.UnknownInterfaceError)
+	AssertTrue(ok, "Unexpected error: %v", err)
+	ExpectEq("io", e.PkgPath)
+	ExpectEq("Frobnicator", e.Name)
+}
+
+func (t *GenerateTest) LoadInterfaces_NotInterface() {
+	_, _, err := generate.LoadInterfaces("", "io", []string{"SectionReader"})
+
+	e, ok := err.(*generate.NotInterfaceError)
+	AssertTrue(ok, "Unexpected error: %v", err)
+	ExpectEq("SectionReader", e.Name)
+}
+
+func (t *GenerateTest) LoadInterfaces_InvalidInstantiation() {
+	_, _, err := generate.LoadInterfaces(
+		"",
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		[]string{"Store[string]"})
+
+	e, ok := err.(*generate.InvalidInstantiationError)
+	AssertTrue(ok, "Unexpected error: %v", err)
+	ExpectEq("Store[string]", e.Expr)
+	ExpectThat(e.Err, Error(HasSubstr("type arguments")))
+}
+
+func (t *GenerateTest) LoadInterfaces_Success() {
+	interfaces, _, err := generate.LoadInterfaces(
+		"",
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		[]string{"Source", "Store[string, int]"})
+
+	AssertEq(nil, err)
+	AssertEq(2, len(interfaces))
+	ExpectEq("Source", interfaces[0].Obj().Name())
+	ExpectEq(1, interfaces[0].TypeParams().Len())
+	ExpectEq("Store", interfaces[1].Obj().Name())
+	ExpectEq(2, interfaces[1].TypeArgs().Len())
+}
+
+func (t *GenerateTest) LoadInterfaces_MethodDocs() {
+	interfaces, docs, err := generate.LoadInterfaces(
+		"",
+		"github.com/jacobsa/oglemock/generate/testdata/generic_pkg",
+		[]string{"Store"})
+
+	AssertEq(nil, err)
+	AssertEq(1, len(interfaces))
+
+	iface := interfaces[0].Underlying().(*types.Interface)
+	for i := 0; i < iface.NumMethods(); i++ {
+		f := iface.Method(i)
+		switch f.Name() {
+		case "Put":
+			ExpectEq("// Put stores value for key.", docs[f.Pos()])
+
+		case "Keys":
+			ExpectEq("", docs[f.Pos()])
+		}
+	}
+}
+
+func (t *GenerateTest) LoadInterfaces_EmbeddedMethodDocs() {
+	interfaces, docs, err := generate.LoadInterfaces(
+		"",
+		"github.com/jacobsa/oglemock/generate/testdata/embedded_pkg",
+		[]string{"Widget"})
+
+	AssertEq(nil, err)
+	AssertEq(1, len(interfaces))
+
+	// Name is declared in the same package, and DoFoo in another one.
+	iface := interfaces[0].Underlying().(*types.Interface)
+	for i := 0; i < iface.NumMethods(); i++ {
+		f := iface.Method(i)
+		switch f.Name() {
+		case "Name":
+			ExpectEq("// Name returns the object's name.", docs[f.Pos()])
+
+		case "DoFoo":
+			ExpectEq("// DoFoo does foo with a.", docs[f.Pos()])
+		}
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// UnknownPackageError is returned by LoadInterfaces when the requested package
+// can't be found.
+type UnknownPackageError struct {
+	PkgPath string
+
+	// The error reported by the build system.
+	Err error
+}
+
+func (e *UnknownPackageError) Error() string {
+	return fmt.Sprintf("Unknown package: %s", e.PkgPath)
+}
+
+// PackageError is returned by LoadInterfaces when the requested package was
+// found, but couldn't be parsed or type-checked.
+type PackageError struct {
+	PkgPath string
+	Errs    []packages.Error
+}
+
+func (e *PackageError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = "    " + err.Error()
+	}
+
+	return fmt.Sprintf(
+		"Errors loading package %s:\n\n%s",
+		e.PkgPath,
+		strings.Join(msgs, "\n"))
+}
+
+// UnknownInterfaceError is returned by LoadInterfaces when the requested
+// package doesn't contain a type with the requested name.
+type UnknownInterfaceError struct {
+	PkgPath string
+	Name    string
+}
+
+func (e *UnknownInterfaceError) Error() string {
+	return fmt.Sprintf("Unknown interface: %s", e.Name)
+}
+
+// NotInterfaceError is returned by LoadInterfaces when a requested type exists
+// but isn't an interface.
+type NotInterfaceError struct {
+	PkgPath string
+	Name    string
+}
+
+func (e *NotInterfaceError) Error() string {
+	return fmt.Sprintf("Not an interface: %s", e.Name)
+}
+
+// InvalidInstantiationError is returned by LoadInterfaces when a requested
+// instantiation of a generic interface, such as "Store[string]", is invalid.
+type InvalidInstantiationError struct {
+	PkgPath string
+	Expr    string
+
+	// The error reported by the type checker.
+	Err error
+}
+
+func (e *InvalidInstantiationError) Error() string {
+	return fmt.Sprintf("Invalid instantiation %s: %v", e.Expr, e.Err)
+}
+
+// MethodDocs maps the positions of interface method declarations to their doc
+// comments, as written in the source including comment markers.
+type MethodDocs map[token.Pos]string
+
+// LoadInterfaces parses and type-checks the package with the supplied import
+// path from source, and returns the interfaces within it with the supplied
+// names, suitable for passing to GenerateMockSourceFromTypes along with the
+// doc comments of the interface methods declared in the package and of any
+// methods the interfaces embed from other packages. A name may also
+// be an instantiation of a generic interface, such as "Store[string, int]",
+// whose type arguments are predeclared types or types from the same package.
+//
+// The package is located by the go command run in the directory dir, or the
+// current directory if dir is empty, so module mode is supported.
+//
+// Errors are of type *UnknownPackageError, *PackageError,
+// *UnknownInterfaceError, *NotInterfaceError, or *InvalidInstantiationError,
+// except for failures to run the go command at all.
+func LoadInterfaces(
+	dir string,
+	pkgPath string,
+	typeNames []string) (interfaces []*types.Named, docs MethodDocs, err error) {
+	return LoadPackageInterfaces(
+		dir,
+		[]PackageInterfaces{{PkgPath: pkgPath, Names: typeNames}})
+}
+
+// PackageInterfaces names interfaces within a single package, for use with
+// LoadPackageInterfaces.
+type PackageInterfaces struct {
+	// The package's import path, or a path relative to the directory in which
+	// packages are loaded such as "./foo".
+	PkgPath string
+
+	// The names of the interfaces, as for LoadInterfaces.
+	Names []string
+}
+
+// LoadPackageInterfaces is like LoadInterfaces, but loads interfaces from any
+// number of packages. The interfaces are returned in the order given, and the
+// doc comments for all of them are returned together.
+func LoadPackageInterfaces(
+	dir string,
+	pkgs []PackageInterfaces) (
+	interfaces []*types.Named,
+	docs MethodDocs,
+	err error) {
+	// Doc comments are keyed by position, so all packages must share a file
+	// set.
+	cfg := &packages.Config{
+		Mode: packages.NeedName |
+			packages.NeedTypes |
+			packages.NeedSyntax |
+			packages.NeedTypesInfo,
+		Dir:  dir,
+		Fset: token.NewFileSet(),
+	}
+
+	docs = make(MethodDocs)
+	for _, p := range pkgs {
+		var named []*types.Named
+		named, err = loadInterfaces(cfg, p.PkgPath, p.Names, docs)
+		if err != nil {
+			return
+		}
+
+		interfaces = append(interfaces, named...)
+	}
+
+	return
+}
+
+// Load the interfaces with the supplied names from a single package, adding
+// doc comments for their methods to docs.
+func loadInterfaces(
+	cfg *packages.Config,
+	pkgPath string,
+	typeNames []string,
+	docs MethodDocs) (interfaces []*types.Named, err error) {
+	// Load the package.
+	pkgs, err := packages.Load(cfg, pkgPath)
+	if err != nil {
+		err = fmt.Errorf("packages.Load: %v", err)
+		return
+	}
+
+	if len(pkgs) != 1 {
+		err = &UnknownPackageError{
+			PkgPath: pkgPath,
+			Err:     fmt.Errorf("Found %d packages", len(pkgs)),
+		}
+
+		return
+	}
+
+	pkg := pkgs[0]
+
+	// Sort out any errors. A failure to list the package means that it
+	// couldn't be found.
+	if len(pkg.Errors) > 0 {
+		for _, e := range pkg.Errors {
+			if e.Kind == packages.ListError {
+				err = &UnknownPackageError{PkgPath: pkgPath, Err: e}
+				return
+			}
+		}
+
+		err = &PackageError{PkgPath: pkgPath, Errs: pkg.Errors}
+		return
+	}
+
+	// Find each type.
+	for _, name := range typeNames {
+		var named *types.Named
+		named, err = findInterface(cfg.Fset, pkg.Types, name)
+		if err != nil {
+			return
+		}
+
+		interfaces = append(interfaces, named)
+	}
+
+	for _, f := range pkg.Syntax {
+		forEachMethodDecl(f, func(name *ast.Ident, doc *ast.CommentGroup) {
+			docs[name.Pos()] = commentText(doc)
+		})
+	}
+
+	addForeignMethodDocs(cfg.Fset, pkg.Types, interfaces, docs)
+
+	return
+}
+
+// Call f for each method declared by an interface type in the supplied file
+// that has a doc comment.
+func forEachMethodDecl(
+	file *ast.File,
+	f func(name *ast.Ident, doc *ast.CommentGroup)) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		it, ok := n.(*ast.InterfaceType)
+		if !ok || it.Methods == nil {
+			return true
+		}
+
+		for _, field := range it.Methods.List {
+			if field.Doc == nil {
+				continue
+			}
+
+			for _, name := range field.Names {
+				f(name, field.Doc)
+			}
+		}
+
+		return true
+	})
+}
+
+// Return the text of the supplied comment, including comment markers.
+func commentText(cg *ast.CommentGroup) string {
+	lines := make([]string, len(cg.List))
+	for i, c := range cg.List {
+		lines[i] = c.Text
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Methods embedded from other packages come from export data rather than
+// source, so there are no syntax trees holding their doc comments. Find them
+// by parsing the files in which the methods are declared, recording them in
+// docs. This is best-effort; files that can't be parsed are skipped.
+func addForeignMethodDocs(
+	fset *token.FileSet,
+	pkg *types.Package,
+	interfaces []*types.Named,
+	docs MethodDocs) {
+	// Find the positions of the methods in question, by file and line.
+	byFile := make(map[string]map[int]*types.Func)
+	for _, named := range interfaces {
+		iface := named.Underlying().(*types.Interface)
+		for i := 0; i < iface.NumMethods(); i++ {
+			f := iface.Method(i)
+			if f.Pkg() == nil || f.Pkg().Path() == pkg.Path() {
+				continue
+			}
+
+			// Export data records the standard library's files relative to
+			// $GOROOT.
+			pos := fset.Position(f.Pos())
+			filename := strings.Replace(pos.Filename, "$GOROOT", build.Default.GOROOT, 1)
+			if filename == "" {
+				continue
+			}
+
+			if byFile[filename] == nil {
+				byFile[filename] = make(map[int]*types.Func)
+			}
+
+			byFile[filename][pos.Line] = f
+		}
+	}
+
+	// Parse each file, matching up the methods declared within it.
+	for filename, byLine := range byFile {
+		fileSet := token.NewFileSet()
+		file, err := parser.ParseFile(fileSet, filename, nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		forEachMethodDecl(file, func(name *ast.Ident, doc *ast.CommentGroup) {
+			f := byLine[fileSet.Position(name.Pos()).Line]
+			if f != nil && f.Name() == name.Name {
+				docs[f.Pos()] = commentText(doc)
+			}
+		})
+	}
+}
+
+// Find the named interface, or instantiation of a generic interface, within
+// the supplied package.
+func findInterface(
+	fset *token.FileSet,
+	pkg *types.Package,
+	name string) (named *types.Named, err error) {
+	// Make sure the type exists.
+	baseName := name
+	if i := strings.Index(name, "["); i >= 0 {
+		baseName = strings.TrimSpace(name[:i])
+	}
+
+	obj, ok := pkg.Scope().Lookup(baseName).(*types.TypeName)
+	if !ok {
+		err = &UnknownInterfaceError{PkgPath: pkg.Path(), Name: baseName}
+		return
+	}
+
+	// Evaluate instantiations.
+	t := obj.Type()
+	if baseName != name {
+		var tv types.TypeAndValue
+		tv, err = types.Eval(fset, pkg, token.NoPos, name)
+		if err != nil {
+			err = &InvalidInstantiationError{
+				PkgPath: pkg.Path(),
+				Expr:    name,
+				Err:     err,
+			}
+
+			return
+		}
+
+		t = tv.Type
+	}
+
+	named, ok = types.Unalias(t).(*types.Named)
+	if !ok || !types.IsInterface(named) {
+		named = nil
+		err = &NotInterfaceError{PkgPath: pkg.Path(), Name: name}
+		return
+	}
+
+	return
+}
@@ -0,0 +1,45 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// A package containing interfaces that embed other interfaces, both from this
+// package and from others.
+package embedded_pkg
+
+import (
+	"io"
+
+	"github.com/jacobsa/oglemock/generate/testdata/renamed_pkg"
+)
+
+type Namer interface {
+	// Name returns the object's name.
+	Name() string
+}
+
+// An interface embedding interfaces from this package and from others, the
+// latter with a package name that differs from its import path.
+type Widget interface {
+	Namer
+	io.ReadCloser
+	tony.SomeInterface
+
+	Frobnicate(level tony.SomeUint8Alias) error
+}
+
+// An interface embedding interfaces whose method sets overlap.
+type File interface {
+	io.ReadCloser
+	io.WriteCloser
+}
@@ -0,0 +1,42 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// A package containing generic interfaces.
+package generic_pkg
+
+import (
+	"io"
+)
+
+type Store[K comparable, V any] interface {
+	// Get returns the value stored for key, and whether there was one.
+	Get(key K) (V, bool)
+
+	// Put stores value for key.
+	Put(key K, value V) error
+
+	Keys() []K
+
+	// Update replaces the value stored for key, and for each of more, with the
+	// result of calling f.
+	Update(key K, f func(V) V, more ...V)
+}
+
+type Source[T io.Reader] interface {
+	Open(name string) (T, error)
+
+	// Copy has parameters whose names can't be used.
+	Copy(io io.Writer, _ int, len int) (n int64, err error)
+}
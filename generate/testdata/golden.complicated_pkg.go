@@ -21,6 +21,10 @@ import (
 type MockComplicatedThing interface {
 	complicated_pkg.ComplicatedThing
 	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockComplicatedThingExpecter
 }
 
 type mockComplicatedThing struct {
@@ -30,11 +34,18 @@ type mockComplicatedThing struct {
 
 func NewMockComplicatedThing(
 	c oglemock.Controller,
-	desc string) MockComplicatedThing {
-	return &mockComplicatedThing{
+	desc string,
+	opts ...oglemock.MockOption) MockComplicatedThing {
+	m := &mockComplicatedThing{
 		controller:  c,
 		description: desc,
 	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
 }
 
 func (m *mockComplicatedThing) Oglemock_Id() uintptr {
@@ -46,6 +57,9 @@ func (m *mockComplicatedThing) Oglemock_Description() string {
 }
 
 func (m *mockComplicatedThing) Arrays(p0 [3]string) (o0 [3]int, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -75,6 +89,9 @@ func (m *mockComplicatedThing) Arrays(p0 [3]string) (o0 [3]int, o1 error) {
 }
 
 func (m *mockComplicatedThing) Channels(p0 chan chan<- <-chan net.Conn) (o0 chan int) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -99,6 +116,9 @@ func (m *mockComplicatedThing) Channels(p0 chan chan<- <-chan net.Conn) (o0 chan
 }
 
 func (m *mockComplicatedThing) EmptyInterface(p0 interface{}) (o0 interface{}, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -128,6 +148,9 @@ func (m *mockComplicatedThing) EmptyInterface(p0 interface{}) (o0 interface{}, o
 }
 
 func (m *mockComplicatedThing) Functions(p0 func(int, image.Image) int) (o0 func(string, int) net.Conn) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -152,6 +175,9 @@ func (m *mockComplicatedThing) Functions(p0 func(int, image.Image) int) (o0 func
 }
 
 func (m *mockComplicatedThing) Maps(p0 map[string]*int) (o0 map[int]*string, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -181,6 +207,9 @@ func (m *mockComplicatedThing) Maps(p0 map[string]*int) (o0 map[int]*string, o1
 }
 
 func (m *mockComplicatedThing) NamedScalarType(p0 complicated_pkg.Byte) (o0 []complicated_pkg.Byte, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -210,6 +239,9 @@ func (m *mockComplicatedThing) NamedScalarType(p0 complicated_pkg.Byte) (o0 []co
 }
 
 func (m *mockComplicatedThing) Pointers(p0 *int, p1 *net.Conn, p2 **io.Reader) (o0 *int, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -239,6 +271,9 @@ func (m *mockComplicatedThing) Pointers(p0 *int, p1 *net.Conn, p2 **io.Reader) (
 }
 
 func (m *mockComplicatedThing) RenamedPackage(p0 tony.SomeUint8Alias) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -258,6 +293,9 @@ func (m *mockComplicatedThing) RenamedPackage(p0 tony.SomeUint8Alias) {
 }
 
 func (m *mockComplicatedThing) Slices(p0 []string) (o0 []int, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -287,6 +325,9 @@ func (m *mockComplicatedThing) Slices(p0 []string) (o0 []int, o1 error) {
 }
 
 func (m *mockComplicatedThing) Variadic(p0 int, p1 ...net.Conn) (o0 int) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -309,3 +350,284 @@ func (m *mockComplicatedThing) Variadic(p0 int, p1 ...net.Conn) (o0 int) {
 
 	return
 }
+
+// MockComplicatedThingExpecter sets up expectations for MockComplicatedThing objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockComplicatedThingExpecter struct {
+	m *mockComplicatedThing
+}
+
+func (m *mockComplicatedThing) EXPECT() *MockComplicatedThingExpecter {
+	return &MockComplicatedThingExpecter{m}
+}
+
+func (e *MockComplicatedThingExpecter) Arrays(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Arrays",
+		file,
+		line)(p0)
+}
+
+func (e *MockComplicatedThingExpecter) Channels(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Channels",
+		file,
+		line)(p0)
+}
+
+func (e *MockComplicatedThingExpecter) EmptyInterface(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"EmptyInterface",
+		file,
+		line)(p0)
+}
+
+func (e *MockComplicatedThingExpecter) Functions(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Functions",
+		file,
+		line)(p0)
+}
+
+func (e *MockComplicatedThingExpecter) Maps(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Maps",
+		file,
+		line)(p0)
+}
+
+func (e *MockComplicatedThingExpecter) NamedScalarType(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"NamedScalarType",
+		file,
+		line)(p0)
+}
+
+func (e *MockComplicatedThingExpecter) Pointers(p0 interface{}, p1 interface{}, p2 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Pointers",
+		file,
+		line)(p0, p1, p2)
+}
+
+func (e *MockComplicatedThingExpecter) RenamedPackage(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"RenamedPackage",
+		file,
+		line)(p0)
+}
+
+func (e *MockComplicatedThingExpecter) Slices(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Slices",
+		file,
+		line)(p0)
+}
+
+func (e *MockComplicatedThingExpecter) Variadic(p0 interface{}, p1 ...interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Variadic",
+		file,
+		line)(append([]interface{}{p0}, p1...)...)
+}
+
+// ComplicatedThingArraysReturns returns an action for MockComplicatedThing.Arrays
+// that returns the supplied values.
+func ComplicatedThingArraysReturns(o0 [3]int, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// ComplicatedThingArraysDo returns an action for MockComplicatedThing.Arrays
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingArraysDo(f func([3]string) ([3]int, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingChannelsReturns returns an action for MockComplicatedThing.Channels
+// that returns the supplied values.
+func ComplicatedThingChannelsReturns(o0 chan int) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ComplicatedThingChannelsDo returns an action for MockComplicatedThing.Channels
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingChannelsDo(f func(chan chan<- <-chan net.Conn) chan int) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingEmptyInterfaceReturns returns an action for MockComplicatedThing.EmptyInterface
+// that returns the supplied values.
+func ComplicatedThingEmptyInterfaceReturns(o0 interface{}, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// ComplicatedThingEmptyInterfaceDo returns an action for MockComplicatedThing.EmptyInterface
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingEmptyInterfaceDo(f func(interface{}) (interface{}, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingFunctionsReturns returns an action for MockComplicatedThing.Functions
+// that returns the supplied values.
+func ComplicatedThingFunctionsReturns(o0 func(string, int) net.Conn) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ComplicatedThingFunctionsDo returns an action for MockComplicatedThing.Functions
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingFunctionsDo(f func(func(int, image.Image) int) func(string, int) net.Conn) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingMapsReturns returns an action for MockComplicatedThing.Maps
+// that returns the supplied values.
+func ComplicatedThingMapsReturns(o0 map[int]*string, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// ComplicatedThingMapsDo returns an action for MockComplicatedThing.Maps
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingMapsDo(f func(map[string]*int) (map[int]*string, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingNamedScalarTypeReturns returns an action for MockComplicatedThing.NamedScalarType
+// that returns the supplied values.
+func ComplicatedThingNamedScalarTypeReturns(o0 []complicated_pkg.Byte, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// ComplicatedThingNamedScalarTypeDo returns an action for MockComplicatedThing.NamedScalarType
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingNamedScalarTypeDo(f func(complicated_pkg.Byte) ([]complicated_pkg.Byte, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingPointersReturns returns an action for MockComplicatedThing.Pointers
+// that returns the supplied values.
+func ComplicatedThingPointersReturns(o0 *int, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// ComplicatedThingPointersDo returns an action for MockComplicatedThing.Pointers
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingPointersDo(f func(*int, *net.Conn, **io.Reader) (*int, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingRenamedPackageReturns returns an action for MockComplicatedThing.RenamedPackage
+// that returns the supplied values.
+func ComplicatedThingRenamedPackageReturns() oglemock.Action {
+	return oglemock.Return()
+}
+
+// ComplicatedThingRenamedPackageDo returns an action for MockComplicatedThing.RenamedPackage
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingRenamedPackageDo(f func(tony.SomeUint8Alias)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingSlicesReturns returns an action for MockComplicatedThing.Slices
+// that returns the supplied values.
+func ComplicatedThingSlicesReturns(o0 []int, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// ComplicatedThingSlicesDo returns an action for MockComplicatedThing.Slices
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingSlicesDo(f func([]string) ([]int, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ComplicatedThingVariadicReturns returns an action for MockComplicatedThing.Variadic
+// that returns the supplied values.
+func ComplicatedThingVariadicReturns(o0 int) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ComplicatedThingVariadicDo returns an action for MockComplicatedThing.Variadic
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ComplicatedThingVariadicDo(f func(int, ...net.Conn) int) oglemock.Action {
+	return oglemock.Invoke(f)
+}
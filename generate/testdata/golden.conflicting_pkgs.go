@@ -0,0 +1,331 @@
+// This file was auto-generated using createmock. See the following page for
+// more information:
+//
+//     https://github.com/jacobsa/oglemock
+//
+
+package pkg
+
+import (
+	fmt "fmt"
+	oglemock "github.com/jacobsa/oglemock"
+	fmt_2 "github.com/jacobsa/oglemock/generate/testdata/other_fmt"
+	v1 "github.com/jacobsa/oglemock/generate/testdata/versioned/a/v1"
+	v1_2 "github.com/jacobsa/oglemock/generate/testdata/versioned/b/v1"
+	runtime "runtime"
+	unsafe "unsafe"
+)
+
+type MockGetter interface {
+	v1.Getter
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockGetterExpecter
+}
+
+type mockGetter struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockGetter(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockGetter {
+	m := &mockGetter{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockGetter) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockGetter) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockGetter) Get(p0 string) (o0 *v1.Thing, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Get",
+		file,
+		line,
+		[]interface{}{p0})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockGetter.Get: invalid return values: %v", retVals))
+	}
+
+	// o0 *v1.Thing
+	if retVals[0] != nil {
+		o0 = retVals[0].(*v1.Thing)
+	}
+
+	// o1 error
+	if retVals[1] != nil {
+		o1 = retVals[1].(error)
+	}
+
+	return
+}
+
+// MockGetterExpecter sets up expectations for MockGetter objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockGetterExpecter struct {
+	m *mockGetter
+}
+
+func (m *mockGetter) EXPECT() *MockGetterExpecter {
+	return &MockGetterExpecter{m}
+}
+
+func (e *MockGetterExpecter) Get(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Get",
+		file,
+		line)(p0)
+}
+
+// GetterGetReturns returns an action for MockGetter.Get
+// that returns the supplied values.
+func GetterGetReturns(o0 *v1.Thing, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// GetterGetDo returns an action for MockGetter.Get
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func GetterGetDo(f func(string) (*v1.Thing, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+type MockSetter interface {
+	v1_2.Setter
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockSetterExpecter
+}
+
+type mockSetter struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockSetter(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockSetter {
+	m := &mockSetter{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockSetter) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockSetter) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockSetter) Set(p0 string, p1 *v1_2.Thing) (o0 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Set",
+		file,
+		line,
+		[]interface{}{p0, p1})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockSetter.Set: invalid return values: %v", retVals))
+	}
+
+	// o0 error
+	if retVals[0] != nil {
+		o0 = retVals[0].(error)
+	}
+
+	return
+}
+
+// MockSetterExpecter sets up expectations for MockSetter objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockSetterExpecter struct {
+	m *mockSetter
+}
+
+func (m *mockSetter) EXPECT() *MockSetterExpecter {
+	return &MockSetterExpecter{m}
+}
+
+func (e *MockSetterExpecter) Set(p0 interface{}, p1 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Set",
+		file,
+		line)(p0, p1)
+}
+
+// SetterSetReturns returns an action for MockSetter.Set
+// that returns the supplied values.
+func SetterSetReturns(o0 error) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// SetterSetDo returns an action for MockSetter.Set
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func SetterSetDo(f func(string, *v1_2.Thing) error) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+type MockPrinter interface {
+	fmt_2.Printer
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockPrinterExpecter
+}
+
+type mockPrinter struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockPrinter(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockPrinter {
+	m := &mockPrinter{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockPrinter) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockPrinter) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockPrinter) Print(p0 fmt_2.Style) (o0 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Print",
+		file,
+		line,
+		[]interface{}{p0})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockPrinter.Print: invalid return values: %v", retVals))
+	}
+
+	// o0 error
+	if retVals[0] != nil {
+		o0 = retVals[0].(error)
+	}
+
+	return
+}
+
+// MockPrinterExpecter sets up expectations for MockPrinter objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockPrinterExpecter struct {
+	m *mockPrinter
+}
+
+func (m *mockPrinter) EXPECT() *MockPrinterExpecter {
+	return &MockPrinterExpecter{m}
+}
+
+func (e *MockPrinterExpecter) Print(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Print",
+		file,
+		line)(p0)
+}
+
+// PrinterPrintReturns returns an action for MockPrinter.Print
+// that returns the supplied values.
+func PrinterPrintReturns(o0 error) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// PrinterPrintDo returns an action for MockPrinter.Print
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func PrinterPrintDo(f func(fmt_2.Style) error) oglemock.Action {
+	return oglemock.Invoke(f)
+}
@@ -0,0 +1,563 @@
+// This file was auto-generated using createmock. See the following page for
+// more information:
+//
+//     https://github.com/jacobsa/oglemock
+//
+
+package pkg
+
+import (
+	fmt "fmt"
+	oglemock "github.com/jacobsa/oglemock"
+	embedded_pkg "github.com/jacobsa/oglemock/generate/testdata/embedded_pkg"
+	tony "github.com/jacobsa/oglemock/generate/testdata/renamed_pkg"
+	runtime "runtime"
+	unsafe "unsafe"
+)
+
+type MockWidget interface {
+	embedded_pkg.Widget
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockWidgetExpecter
+}
+
+type mockWidget struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockWidget(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockWidget {
+	m := &mockWidget{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockWidget) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockWidget) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockWidget) Close() (o0 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Close",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockWidget.Close: invalid return values: %v", retVals))
+	}
+
+	// o0 error
+	if retVals[0] != nil {
+		o0 = retVals[0].(error)
+	}
+
+	return
+}
+
+// DoFoo does foo with a.
+func (m *mockWidget) DoFoo(a int) (o0 int) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"DoFoo",
+		file,
+		line,
+		[]interface{}{a})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockWidget.DoFoo: invalid return values: %v", retVals))
+	}
+
+	// o0 int
+	if retVals[0] != nil {
+		o0 = retVals[0].(int)
+	}
+
+	return
+}
+
+func (m *mockWidget) Frobnicate(level tony.SomeUint8Alias) (o0 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Frobnicate",
+		file,
+		line,
+		[]interface{}{level})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockWidget.Frobnicate: invalid return values: %v", retVals))
+	}
+
+	// o0 error
+	if retVals[0] != nil {
+		o0 = retVals[0].(error)
+	}
+
+	return
+}
+
+// Name returns the object's name.
+func (m *mockWidget) Name() (o0 string) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Name",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockWidget.Name: invalid return values: %v", retVals))
+	}
+
+	// o0 string
+	if retVals[0] != nil {
+		o0 = retVals[0].(string)
+	}
+
+	return
+}
+
+func (m *mockWidget) Read(p []byte) (n int, err error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Read",
+		file,
+		line,
+		[]interface{}{p})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockWidget.Read: invalid return values: %v", retVals))
+	}
+
+	// n int
+	if retVals[0] != nil {
+		n = retVals[0].(int)
+	}
+
+	// err error
+	if retVals[1] != nil {
+		err = retVals[1].(error)
+	}
+
+	return
+}
+
+// MockWidgetExpecter sets up expectations for MockWidget objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockWidgetExpecter struct {
+	m *mockWidget
+}
+
+func (m *mockWidget) EXPECT() *MockWidgetExpecter {
+	return &MockWidgetExpecter{m}
+}
+
+func (e *MockWidgetExpecter) Close() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Close",
+		file,
+		line)()
+}
+
+func (e *MockWidgetExpecter) DoFoo(a interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"DoFoo",
+		file,
+		line)(a)
+}
+
+func (e *MockWidgetExpecter) Frobnicate(level interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Frobnicate",
+		file,
+		line)(level)
+}
+
+func (e *MockWidgetExpecter) Name() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Name",
+		file,
+		line)()
+}
+
+func (e *MockWidgetExpecter) Read(p interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Read",
+		file,
+		line)(p)
+}
+
+// WidgetCloseReturns returns an action for MockWidget.Close
+// that returns the supplied values.
+func WidgetCloseReturns(o0 error) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// WidgetCloseDo returns an action for MockWidget.Close
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func WidgetCloseDo(f func() error) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// WidgetDoFooReturns returns an action for MockWidget.DoFoo
+// that returns the supplied values.
+func WidgetDoFooReturns(o0 int) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// WidgetDoFooDo returns an action for MockWidget.DoFoo
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func WidgetDoFooDo(f func(int) int) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// WidgetFrobnicateReturns returns an action for MockWidget.Frobnicate
+// that returns the supplied values.
+func WidgetFrobnicateReturns(o0 error) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// WidgetFrobnicateDo returns an action for MockWidget.Frobnicate
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func WidgetFrobnicateDo(f func(tony.SomeUint8Alias) error) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// WidgetNameReturns returns an action for MockWidget.Name
+// that returns the supplied values.
+func WidgetNameReturns(o0 string) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// WidgetNameDo returns an action for MockWidget.Name
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func WidgetNameDo(f func() string) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// WidgetReadReturns returns an action for MockWidget.Read
+// that returns the supplied values.
+func WidgetReadReturns(n int, err error) oglemock.Action {
+	return oglemock.Return(n, err)
+}
+
+// WidgetReadDo returns an action for MockWidget.Read
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func WidgetReadDo(f func([]byte) (int, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+type MockFile interface {
+	embedded_pkg.File
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockFileExpecter
+}
+
+type mockFile struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockFile(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockFile {
+	m := &mockFile{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockFile) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockFile) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockFile) Close() (o0 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Close",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockFile.Close: invalid return values: %v", retVals))
+	}
+
+	// o0 error
+	if retVals[0] != nil {
+		o0 = retVals[0].(error)
+	}
+
+	return
+}
+
+func (m *mockFile) Read(p []byte) (n int, err error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Read",
+		file,
+		line,
+		[]interface{}{p})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockFile.Read: invalid return values: %v", retVals))
+	}
+
+	// n int
+	if retVals[0] != nil {
+		n = retVals[0].(int)
+	}
+
+	// err error
+	if retVals[1] != nil {
+		err = retVals[1].(error)
+	}
+
+	return
+}
+
+func (m *mockFile) Write(p []byte) (n int, err error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Write",
+		file,
+		line,
+		[]interface{}{p})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockFile.Write: invalid return values: %v", retVals))
+	}
+
+	// n int
+	if retVals[0] != nil {
+		n = retVals[0].(int)
+	}
+
+	// err error
+	if retVals[1] != nil {
+		err = retVals[1].(error)
+	}
+
+	return
+}
+
+// MockFileExpecter sets up expectations for MockFile objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockFileExpecter struct {
+	m *mockFile
+}
+
+func (m *mockFile) EXPECT() *MockFileExpecter {
+	return &MockFileExpecter{m}
+}
+
+func (e *MockFileExpecter) Close() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Close",
+		file,
+		line)()
+}
+
+func (e *MockFileExpecter) Read(p interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Read",
+		file,
+		line)(p)
+}
+
+func (e *MockFileExpecter) Write(p interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Write",
+		file,
+		line)(p)
+}
+
+// FileCloseReturns returns an action for MockFile.Close
+// that returns the supplied values.
+func FileCloseReturns(o0 error) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// FileCloseDo returns an action for MockFile.Close
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func FileCloseDo(f func() error) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// FileReadReturns returns an action for MockFile.Read
+// that returns the supplied values.
+func FileReadReturns(n int, err error) oglemock.Action {
+	return oglemock.Return(n, err)
+}
+
+// FileReadDo returns an action for MockFile.Read
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func FileReadDo(f func([]byte) (int, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// FileWriteReturns returns an action for MockFile.Write
+// that returns the supplied values.
+func FileWriteReturns(n int, err error) oglemock.Action {
+	return oglemock.Return(n, err)
+}
+
+// FileWriteDo returns an action for MockFile.Write
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func FileWriteDo(f func([]byte) (int, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
@@ -0,0 +1,123 @@
+// This file was auto-generated using createmock. See the following page for
+// more information:
+//
+//     https://github.com/jacobsa/oglemock
+//
+
+package pkg
+
+import (
+	fmt "fmt"
+	oglemock "github.com/jacobsa/oglemock"
+	file_2 "github.com/jacobsa/oglemock/generate/testdata/file"
+	runtime "runtime"
+	unsafe "unsafe"
+)
+
+type MockStatter interface {
+	file_2.Statter
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockStatterExpecter
+}
+
+type mockStatter struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockStatter(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockStatter {
+	m := &mockStatter{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockStatter) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockStatter) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockStatter) Stat(name string) (o0 file_2.Info, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Stat",
+		file,
+		line,
+		[]interface{}{name})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockStatter.Stat: invalid return values: %v", retVals))
+	}
+
+	// o0 file_2.Info
+	if retVals[0] != nil {
+		o0 = retVals[0].(file_2.Info)
+	}
+
+	// o1 error
+	if retVals[1] != nil {
+		o1 = retVals[1].(error)
+	}
+
+	return
+}
+
+// MockStatterExpecter sets up expectations for MockStatter objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockStatterExpecter struct {
+	m *mockStatter
+}
+
+func (m *mockStatter) EXPECT() *MockStatterExpecter {
+	return &MockStatterExpecter{m}
+}
+
+func (e *MockStatterExpecter) Stat(name interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Stat",
+		file,
+		line)(name)
+}
+
+// StatterStatReturns returns an action for MockStatter.Stat
+// that returns the supplied values.
+func StatterStatReturns(o0 file_2.Info, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// StatterStatDo returns an action for MockStatter.Stat
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StatterStatDo(f func(string) (file_2.Info, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
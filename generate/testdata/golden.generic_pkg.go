@@ -0,0 +1,453 @@
+// This file was auto-generated using createmock. See the following page for
+// more information:
+//
+//     https://github.com/jacobsa/oglemock
+//
+
+package pkg
+
+import (
+	fmt "fmt"
+	oglemock "github.com/jacobsa/oglemock"
+	generic_pkg "github.com/jacobsa/oglemock/generate/testdata/generic_pkg"
+	io "io"
+	runtime "runtime"
+	unsafe "unsafe"
+)
+
+type MockStore[K comparable, V any] interface {
+	generic_pkg.Store[K, V]
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockStoreExpecter[K, V]
+}
+
+type mockStore[K comparable, V any] struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockStore[K comparable, V any](
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockStore[K, V] {
+	m := &mockStore[K, V]{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockStore[K, V]) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockStore[K, V]) Oglemock_Description() string {
+	return m.description
+}
+
+// Get returns the value stored for key, and whether there was one.
+func (m *mockStore[K, V]) Get(key K) (o0 V, o1 bool) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Get",
+		file,
+		line,
+		[]interface{}{key})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockStore.Get: invalid return values: %v", retVals))
+	}
+
+	// o0 V
+	if retVals[0] != nil {
+		o0 = retVals[0].(V)
+	}
+
+	// o1 bool
+	if retVals[1] != nil {
+		o1 = retVals[1].(bool)
+	}
+
+	return
+}
+
+func (m *mockStore[K, V]) Keys() (o0 []K) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Keys",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockStore.Keys: invalid return values: %v", retVals))
+	}
+
+	// o0 []K
+	if retVals[0] != nil {
+		o0 = retVals[0].([]K)
+	}
+
+	return
+}
+
+// Put stores value for key.
+func (m *mockStore[K, V]) Put(key K, value V) (o0 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Put",
+		file,
+		line,
+		[]interface{}{key, value})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockStore.Put: invalid return values: %v", retVals))
+	}
+
+	// o0 error
+	if retVals[0] != nil {
+		o0 = retVals[0].(error)
+	}
+
+	return
+}
+
+// Update replaces the value stored for key, and for each of more, with the
+// result of calling f.
+func (m *mockStore[K, V]) Update(key K, p1 func(V) V, more ...V) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Update",
+		file,
+		line,
+		[]interface{}{key, p1, more})
+
+	if len(retVals) != 0 {
+		panic(fmt.Sprintf("mockStore.Update: invalid return values: %v", retVals))
+	}
+
+	return
+}
+
+// MockStoreExpecter sets up expectations for MockStore objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockStoreExpecter[K comparable, V any] struct {
+	m *mockStore[K, V]
+}
+
+func (m *mockStore[K, V]) EXPECT() *MockStoreExpecter[K, V] {
+	return &MockStoreExpecter[K, V]{m}
+}
+
+func (e *MockStoreExpecter[K, V]) Get(key interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Get",
+		file,
+		line)(key)
+}
+
+func (e *MockStoreExpecter[K, V]) Keys() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Keys",
+		file,
+		line)()
+}
+
+func (e *MockStoreExpecter[K, V]) Put(key interface{}, value interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Put",
+		file,
+		line)(key, value)
+}
+
+func (e *MockStoreExpecter[K, V]) Update(key interface{}, p1 interface{}, more ...interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Update",
+		file,
+		line)(append([]interface{}{key, p1}, more...)...)
+}
+
+// StoreGetReturns returns an action for MockStore.Get
+// that returns the supplied values.
+func StoreGetReturns[K comparable, V any](o0 V, o1 bool) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// StoreGetDo returns an action for MockStore.Get
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StoreGetDo[K comparable, V any](f func(K) (V, bool)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// StoreKeysReturns returns an action for MockStore.Keys
+// that returns the supplied values.
+func StoreKeysReturns[K comparable, V any](o0 []K) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// StoreKeysDo returns an action for MockStore.Keys
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StoreKeysDo[K comparable, V any](f func() []K) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// StorePutReturns returns an action for MockStore.Put
+// that returns the supplied values.
+func StorePutReturns[K comparable, V any](o0 error) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// StorePutDo returns an action for MockStore.Put
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StorePutDo[K comparable, V any](f func(K, V) error) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// StoreUpdateReturns returns an action for MockStore.Update
+// that returns the supplied values.
+func StoreUpdateReturns[K comparable, V any]() oglemock.Action {
+	return oglemock.Return()
+}
+
+// StoreUpdateDo returns an action for MockStore.Update
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StoreUpdateDo[K comparable, V any](f func(K, func(V) V, ...V)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+type MockSource[T io.Reader] interface {
+	generic_pkg.Source[T]
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockSourceExpecter[T]
+}
+
+type mockSource[T io.Reader] struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockSource[T io.Reader](
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockSource[T] {
+	m := &mockSource[T]{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockSource[T]) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockSource[T]) Oglemock_Description() string {
+	return m.description
+}
+
+// Copy has parameters whose names can't be used.
+func (m *mockSource[T]) Copy(p0 io.Writer, p1 int, p2 int) (n int64, err error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Copy",
+		file,
+		line,
+		[]interface{}{p0, p1, p2})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockSource.Copy: invalid return values: %v", retVals))
+	}
+
+	// n int64
+	if retVals[0] != nil {
+		n = retVals[0].(int64)
+	}
+
+	// err error
+	if retVals[1] != nil {
+		err = retVals[1].(error)
+	}
+
+	return
+}
+
+func (m *mockSource[T]) Open(name string) (o0 T, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Open",
+		file,
+		line,
+		[]interface{}{name})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockSource.Open: invalid return values: %v", retVals))
+	}
+
+	// o0 T
+	if retVals[0] != nil {
+		o0 = retVals[0].(T)
+	}
+
+	// o1 error
+	if retVals[1] != nil {
+		o1 = retVals[1].(error)
+	}
+
+	return
+}
+
+// MockSourceExpecter sets up expectations for MockSource objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockSourceExpecter[T io.Reader] struct {
+	m *mockSource[T]
+}
+
+func (m *mockSource[T]) EXPECT() *MockSourceExpecter[T] {
+	return &MockSourceExpecter[T]{m}
+}
+
+func (e *MockSourceExpecter[T]) Copy(p0 interface{}, p1 interface{}, p2 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Copy",
+		file,
+		line)(p0, p1, p2)
+}
+
+func (e *MockSourceExpecter[T]) Open(name interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Open",
+		file,
+		line)(name)
+}
+
+// SourceCopyReturns returns an action for MockSource.Copy
+// that returns the supplied values.
+func SourceCopyReturns[T io.Reader](n int64, err error) oglemock.Action {
+	return oglemock.Return(n, err)
+}
+
+// SourceCopyDo returns an action for MockSource.Copy
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func SourceCopyDo[T io.Reader](f func(io.Writer, int, int) (int64, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// SourceOpenReturns returns an action for MockSource.Open
+// that returns the supplied values.
+func SourceOpenReturns[T io.Reader](o0 T, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// SourceOpenDo returns an action for MockSource.Open
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func SourceOpenDo[T io.Reader](f func(string) (T, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
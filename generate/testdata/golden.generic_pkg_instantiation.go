@@ -0,0 +1,284 @@
+// This file was auto-generated using createmock. See the following page for
+// more information:
+//
+//     https://github.com/jacobsa/oglemock
+//
+
+package pkg
+
+import (
+	fmt "fmt"
+	oglemock "github.com/jacobsa/oglemock"
+	generic_pkg "github.com/jacobsa/oglemock/generate/testdata/generic_pkg"
+	runtime "runtime"
+	unsafe "unsafe"
+)
+
+type MockStore interface {
+	generic_pkg.Store[string, int]
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockStoreExpecter
+}
+
+type mockStore struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockStore(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockStore {
+	m := &mockStore{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockStore) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockStore) Oglemock_Description() string {
+	return m.description
+}
+
+// Get returns the value stored for key, and whether there was one.
+func (m *mockStore) Get(key string) (o0 int, o1 bool) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Get",
+		file,
+		line,
+		[]interface{}{key})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockStore.Get: invalid return values: %v", retVals))
+	}
+
+	// o0 int
+	if retVals[0] != nil {
+		o0 = retVals[0].(int)
+	}
+
+	// o1 bool
+	if retVals[1] != nil {
+		o1 = retVals[1].(bool)
+	}
+
+	return
+}
+
+func (m *mockStore) Keys() (o0 []string) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Keys",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockStore.Keys: invalid return values: %v", retVals))
+	}
+
+	// o0 []string
+	if retVals[0] != nil {
+		o0 = retVals[0].([]string)
+	}
+
+	return
+}
+
+// Put stores value for key.
+func (m *mockStore) Put(key string, value int) (o0 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Put",
+		file,
+		line,
+		[]interface{}{key, value})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockStore.Put: invalid return values: %v", retVals))
+	}
+
+	// o0 error
+	if retVals[0] != nil {
+		o0 = retVals[0].(error)
+	}
+
+	return
+}
+
+// Update replaces the value stored for key, and for each of more, with the
+// result of calling f.
+func (m *mockStore) Update(key string, p1 func(int) int, more ...int) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Update",
+		file,
+		line,
+		[]interface{}{key, p1, more})
+
+	if len(retVals) != 0 {
+		panic(fmt.Sprintf("mockStore.Update: invalid return values: %v", retVals))
+	}
+
+	return
+}
+
+// MockStoreExpecter sets up expectations for MockStore objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockStoreExpecter struct {
+	m *mockStore
+}
+
+func (m *mockStore) EXPECT() *MockStoreExpecter {
+	return &MockStoreExpecter{m}
+}
+
+func (e *MockStoreExpecter) Get(key interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Get",
+		file,
+		line)(key)
+}
+
+func (e *MockStoreExpecter) Keys() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Keys",
+		file,
+		line)()
+}
+
+func (e *MockStoreExpecter) Put(key interface{}, value interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Put",
+		file,
+		line)(key, value)
+}
+
+func (e *MockStoreExpecter) Update(key interface{}, p1 interface{}, more ...interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Update",
+		file,
+		line)(append([]interface{}{key, p1}, more...)...)
+}
+
+// StoreGetReturns returns an action for MockStore.Get
+// that returns the supplied values.
+func StoreGetReturns(o0 int, o1 bool) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// StoreGetDo returns an action for MockStore.Get
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StoreGetDo(f func(string) (int, bool)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// StoreKeysReturns returns an action for MockStore.Keys
+// that returns the supplied values.
+func StoreKeysReturns(o0 []string) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// StoreKeysDo returns an action for MockStore.Keys
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StoreKeysDo(f func() []string) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// StorePutReturns returns an action for MockStore.Put
+// that returns the supplied values.
+func StorePutReturns(o0 error) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// StorePutDo returns an action for MockStore.Put
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StorePutDo(f func(string, int) error) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// StoreUpdateReturns returns an action for MockStore.Update
+// that returns the supplied values.
+func StoreUpdateReturns() oglemock.Action {
+	return oglemock.Return()
+}
+
+// StoreUpdateDo returns an action for MockStore.Update
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func StoreUpdateDo(f func(string, func(int) int, ...int)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
@@ -18,6 +18,10 @@ import (
 type MockImage interface {
 	image.Image
 	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockImageExpecter
 }
 
 type mockImage struct {
@@ -27,11 +31,18 @@ type mockImage struct {
 
 func NewMockImage(
 	c oglemock.Controller,
-	desc string) MockImage {
-	return &mockImage{
+	desc string,
+	opts ...oglemock.MockOption) MockImage {
+	m := &mockImage{
 		controller:  c,
 		description: desc,
 	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
 }
 
 func (m *mockImage) Oglemock_Id() uintptr {
@@ -43,6 +54,9 @@ func (m *mockImage) Oglemock_Description() string {
 }
 
 func (m *mockImage) At(p0 int, p1 int) (o0 color.Color) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -67,6 +81,9 @@ func (m *mockImage) At(p0 int, p1 int) (o0 color.Color) {
 }
 
 func (m *mockImage) Bounds() (o0 image.Rectangle) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -91,6 +108,9 @@ func (m *mockImage) Bounds() (o0 image.Rectangle) {
 }
 
 func (m *mockImage) ColorModel() (o0 color.Model) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -114,9 +134,105 @@ func (m *mockImage) ColorModel() (o0 color.Model) {
 	return
 }
 
+// MockImageExpecter sets up expectations for MockImage objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockImageExpecter struct {
+	m *mockImage
+}
+
+func (m *mockImage) EXPECT() *MockImageExpecter {
+	return &MockImageExpecter{m}
+}
+
+func (e *MockImageExpecter) At(p0 interface{}, p1 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"At",
+		file,
+		line)(p0, p1)
+}
+
+func (e *MockImageExpecter) Bounds() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Bounds",
+		file,
+		line)()
+}
+
+func (e *MockImageExpecter) ColorModel() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"ColorModel",
+		file,
+		line)()
+}
+
+// ImageAtReturns returns an action for MockImage.At
+// that returns the supplied values.
+func ImageAtReturns(o0 color.Color) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ImageAtDo returns an action for MockImage.At
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ImageAtDo(f func(int, int) color.Color) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ImageBoundsReturns returns an action for MockImage.Bounds
+// that returns the supplied values.
+func ImageBoundsReturns(o0 image.Rectangle) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ImageBoundsDo returns an action for MockImage.Bounds
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ImageBoundsDo(f func() image.Rectangle) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ImageColorModelReturns returns an action for MockImage.ColorModel
+// that returns the supplied values.
+func ImageColorModelReturns(o0 color.Model) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ImageColorModelDo returns an action for MockImage.ColorModel
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ImageColorModelDo(f func() color.Model) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
 type MockPalettedImage interface {
 	image.PalettedImage
 	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockPalettedImageExpecter
 }
 
 type mockPalettedImage struct {
@@ -126,11 +242,18 @@ type mockPalettedImage struct {
 
 func NewMockPalettedImage(
 	c oglemock.Controller,
-	desc string) MockPalettedImage {
-	return &mockPalettedImage{
+	desc string,
+	opts ...oglemock.MockOption) MockPalettedImage {
+	m := &mockPalettedImage{
 		controller:  c,
 		description: desc,
 	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
 }
 
 func (m *mockPalettedImage) Oglemock_Id() uintptr {
@@ -142,6 +265,9 @@ func (m *mockPalettedImage) Oglemock_Description() string {
 }
 
 func (m *mockPalettedImage) At(p0 int, p1 int) (o0 color.Color) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -166,6 +292,9 @@ func (m *mockPalettedImage) At(p0 int, p1 int) (o0 color.Color) {
 }
 
 func (m *mockPalettedImage) Bounds() (o0 image.Rectangle) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -190,6 +319,9 @@ func (m *mockPalettedImage) Bounds() (o0 image.Rectangle) {
 }
 
 func (m *mockPalettedImage) ColorIndexAt(p0 int, p1 int) (o0 uint8) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -214,6 +346,9 @@ func (m *mockPalettedImage) ColorIndexAt(p0 int, p1 int) (o0 uint8) {
 }
 
 func (m *mockPalettedImage) ColorModel() (o0 color.Model) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -236,3 +371,122 @@ func (m *mockPalettedImage) ColorModel() (o0 color.Model) {
 
 	return
 }
+
+// MockPalettedImageExpecter sets up expectations for MockPalettedImage objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockPalettedImageExpecter struct {
+	m *mockPalettedImage
+}
+
+func (m *mockPalettedImage) EXPECT() *MockPalettedImageExpecter {
+	return &MockPalettedImageExpecter{m}
+}
+
+func (e *MockPalettedImageExpecter) At(p0 interface{}, p1 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"At",
+		file,
+		line)(p0, p1)
+}
+
+func (e *MockPalettedImageExpecter) Bounds() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Bounds",
+		file,
+		line)()
+}
+
+func (e *MockPalettedImageExpecter) ColorIndexAt(p0 interface{}, p1 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"ColorIndexAt",
+		file,
+		line)(p0, p1)
+}
+
+func (e *MockPalettedImageExpecter) ColorModel() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"ColorModel",
+		file,
+		line)()
+}
+
+// PalettedImageAtReturns returns an action for MockPalettedImage.At
+// that returns the supplied values.
+func PalettedImageAtReturns(o0 color.Color) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// PalettedImageAtDo returns an action for MockPalettedImage.At
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func PalettedImageAtDo(f func(int, int) color.Color) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// PalettedImageBoundsReturns returns an action for MockPalettedImage.Bounds
+// that returns the supplied values.
+func PalettedImageBoundsReturns(o0 image.Rectangle) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// PalettedImageBoundsDo returns an action for MockPalettedImage.Bounds
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func PalettedImageBoundsDo(f func() image.Rectangle) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// PalettedImageColorIndexAtReturns returns an action for MockPalettedImage.ColorIndexAt
+// that returns the supplied values.
+func PalettedImageColorIndexAtReturns(o0 uint8) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// PalettedImageColorIndexAtDo returns an action for MockPalettedImage.ColorIndexAt
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func PalettedImageColorIndexAtDo(f func(int, int) uint8) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// PalettedImageColorModelReturns returns an action for MockPalettedImage.ColorModel
+// that returns the supplied values.
+func PalettedImageColorModelReturns(o0 color.Model) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// PalettedImageColorModelDo returns an action for MockPalettedImage.ColorModel
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func PalettedImageColorModelDo(f func() color.Model) oglemock.Action {
+	return oglemock.Invoke(f)
+}
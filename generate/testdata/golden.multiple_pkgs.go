@@ -0,0 +1,440 @@
+// This file was auto-generated using createmock. See the following page for
+// more information:
+//
+//     https://github.com/jacobsa/oglemock
+//
+
+package pkg
+
+import (
+	fmt "fmt"
+	oglemock "github.com/jacobsa/oglemock"
+	tony "github.com/jacobsa/oglemock/generate/testdata/renamed_pkg"
+	image "image"
+	color "image/color"
+	io "io"
+	runtime "runtime"
+	unsafe "unsafe"
+)
+
+type MockReader interface {
+	io.Reader
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockReaderExpecter
+}
+
+type mockReader struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockReader(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockReader {
+	m := &mockReader{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockReader) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockReader) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockReader) Read(p0 []uint8) (o0 int, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Read",
+		file,
+		line,
+		[]interface{}{p0})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockReader.Read: invalid return values: %v", retVals))
+	}
+
+	// o0 int
+	if retVals[0] != nil {
+		o0 = retVals[0].(int)
+	}
+
+	// o1 error
+	if retVals[1] != nil {
+		o1 = retVals[1].(error)
+	}
+
+	return
+}
+
+// MockReaderExpecter sets up expectations for MockReader objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockReaderExpecter struct {
+	m *mockReader
+}
+
+func (m *mockReader) EXPECT() *MockReaderExpecter {
+	return &MockReaderExpecter{m}
+}
+
+func (e *MockReaderExpecter) Read(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Read",
+		file,
+		line)(p0)
+}
+
+// ReaderReadReturns returns an action for MockReader.Read
+// that returns the supplied values.
+func ReaderReadReturns(o0 int, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// ReaderReadDo returns an action for MockReader.Read
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ReaderReadDo(f func([]uint8) (int, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+type MockImage interface {
+	image.Image
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockImageExpecter
+}
+
+type mockImage struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockImage(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockImage {
+	m := &mockImage{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockImage) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockImage) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockImage) At(p0 int, p1 int) (o0 color.Color) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"At",
+		file,
+		line,
+		[]interface{}{p0, p1})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockImage.At: invalid return values: %v", retVals))
+	}
+
+	// o0 color.Color
+	if retVals[0] != nil {
+		o0 = retVals[0].(color.Color)
+	}
+
+	return
+}
+
+func (m *mockImage) Bounds() (o0 image.Rectangle) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Bounds",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockImage.Bounds: invalid return values: %v", retVals))
+	}
+
+	// o0 image.Rectangle
+	if retVals[0] != nil {
+		o0 = retVals[0].(image.Rectangle)
+	}
+
+	return
+}
+
+func (m *mockImage) ColorModel() (o0 color.Model) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"ColorModel",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockImage.ColorModel: invalid return values: %v", retVals))
+	}
+
+	// o0 color.Model
+	if retVals[0] != nil {
+		o0 = retVals[0].(color.Model)
+	}
+
+	return
+}
+
+// MockImageExpecter sets up expectations for MockImage objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockImageExpecter struct {
+	m *mockImage
+}
+
+func (m *mockImage) EXPECT() *MockImageExpecter {
+	return &MockImageExpecter{m}
+}
+
+func (e *MockImageExpecter) At(p0 interface{}, p1 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"At",
+		file,
+		line)(p0, p1)
+}
+
+func (e *MockImageExpecter) Bounds() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Bounds",
+		file,
+		line)()
+}
+
+func (e *MockImageExpecter) ColorModel() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"ColorModel",
+		file,
+		line)()
+}
+
+// ImageAtReturns returns an action for MockImage.At
+// that returns the supplied values.
+func ImageAtReturns(o0 color.Color) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ImageAtDo returns an action for MockImage.At
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ImageAtDo(f func(int, int) color.Color) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ImageBoundsReturns returns an action for MockImage.Bounds
+// that returns the supplied values.
+func ImageBoundsReturns(o0 image.Rectangle) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ImageBoundsDo returns an action for MockImage.Bounds
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ImageBoundsDo(f func() image.Rectangle) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ImageColorModelReturns returns an action for MockImage.ColorModel
+// that returns the supplied values.
+func ImageColorModelReturns(o0 color.Model) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ImageColorModelDo returns an action for MockImage.ColorModel
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ImageColorModelDo(f func() color.Model) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+type MockSomeInterface interface {
+	tony.SomeInterface
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockSomeInterfaceExpecter
+}
+
+type mockSomeInterface struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockSomeInterface(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockSomeInterface {
+	m := &mockSomeInterface{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockSomeInterface) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockSomeInterface) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockSomeInterface) DoFoo(p0 int) (o0 int) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"DoFoo",
+		file,
+		line,
+		[]interface{}{p0})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockSomeInterface.DoFoo: invalid return values: %v", retVals))
+	}
+
+	// o0 int
+	if retVals[0] != nil {
+		o0 = retVals[0].(int)
+	}
+
+	return
+}
+
+// MockSomeInterfaceExpecter sets up expectations for MockSomeInterface objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockSomeInterfaceExpecter struct {
+	m *mockSomeInterface
+}
+
+func (m *mockSomeInterface) EXPECT() *MockSomeInterfaceExpecter {
+	return &MockSomeInterfaceExpecter{m}
+}
+
+func (e *MockSomeInterfaceExpecter) DoFoo(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"DoFoo",
+		file,
+		line)(p0)
+}
+
+// SomeInterfaceDoFooReturns returns an action for MockSomeInterface.DoFoo
+// that returns the supplied values.
+func SomeInterfaceDoFooReturns(o0 int) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// SomeInterfaceDoFooDo returns an action for MockSomeInterface.DoFoo
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func SomeInterfaceDoFooDo(f func(int) int) oglemock.Action {
+	return oglemock.Invoke(f)
+}
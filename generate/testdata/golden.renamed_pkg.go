@@ -17,6 +17,10 @@ import (
 type MockSomeInterface interface {
 	tony.SomeInterface
 	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockSomeInterfaceExpecter
 }
 
 type mockSomeInterface struct {
@@ -26,11 +30,18 @@ type mockSomeInterface struct {
 
 func NewMockSomeInterface(
 	c oglemock.Controller,
-	desc string) MockSomeInterface {
-	return &mockSomeInterface{
+	desc string,
+	opts ...oglemock.MockOption) MockSomeInterface {
+	m := &mockSomeInterface{
 		controller:  c,
 		description: desc,
 	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
 }
 
 func (m *mockSomeInterface) Oglemock_Id() uintptr {
@@ -42,6 +53,9 @@ func (m *mockSomeInterface) Oglemock_Description() string {
 }
 
 func (m *mockSomeInterface) DoFoo(p0 int) (o0 int) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -64,3 +78,41 @@ func (m *mockSomeInterface) DoFoo(p0 int) (o0 int) {
 
 	return
 }
+
+// MockSomeInterfaceExpecter sets up expectations for MockSomeInterface objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockSomeInterfaceExpecter struct {
+	m *mockSomeInterface
+}
+
+func (m *mockSomeInterface) EXPECT() *MockSomeInterfaceExpecter {
+	return &MockSomeInterfaceExpecter{m}
+}
+
+func (e *MockSomeInterfaceExpecter) DoFoo(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"DoFoo",
+		file,
+		line)(p0)
+}
+
+// SomeInterfaceDoFooReturns returns an action for MockSomeInterface.DoFoo
+// that returns the supplied values.
+func SomeInterfaceDoFooReturns(o0 int) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// SomeInterfaceDoFooDo returns an action for MockSomeInterface.DoFoo
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func SomeInterfaceDoFooDo(f func(int) int) oglemock.Action {
+	return oglemock.Invoke(f)
+}
@@ -0,0 +1,352 @@
+// This file was auto-generated using createmock. See the following page for
+// more information:
+//
+//     https://github.com/jacobsa/oglemock
+//
+
+package hidden
+
+import (
+	fmt "fmt"
+	oglemock "github.com/jacobsa/oglemock"
+	runtime "runtime"
+	time "time"
+	unsafe "unsafe"
+)
+
+type mockTokenSource interface {
+	tokenSource
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *mockTokenSourceExpecter
+}
+
+type mockTokenSourceImpl struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func newMockTokenSource(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) mockTokenSource {
+	m := &mockTokenSourceImpl{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockTokenSourceImpl) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockTokenSourceImpl) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockTokenSourceImpl) Close() (o0 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Close",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockTokenSourceImpl.Close: invalid return values: %v", retVals))
+	}
+
+	// o0 error
+	if retVals[0] != nil {
+		o0 = retVals[0].(error)
+	}
+
+	return
+}
+
+// next returns the next token, waiting no longer than the supplied timeout.
+func (m *mockTokenSourceImpl) next(timeout time.Duration) (o0 *token, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"next",
+		file,
+		line,
+		[]interface{}{timeout})
+
+	if len(retVals) != 2 {
+		panic(fmt.Sprintf("mockTokenSourceImpl.next: invalid return values: %v", retVals))
+	}
+
+	// o0 *token
+	if retVals[0] != nil {
+		o0 = retVals[0].(*token)
+	}
+
+	// o1 error
+	if retVals[1] != nil {
+		o1 = retVals[1].(error)
+	}
+
+	return
+}
+
+func (m *mockTokenSourceImpl) Oglemock_MethodSignature(methodName string) interface{} {
+	switch methodName {
+
+	case "next":
+		return (func(time.Duration) (*token, error))(nil)
+
+	}
+
+	return nil
+}
+
+// mockTokenSourceExpecter sets up expectations for mockTokenSource objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type mockTokenSourceExpecter struct {
+	m *mockTokenSourceImpl
+}
+
+func (m *mockTokenSourceImpl) EXPECT() *mockTokenSourceExpecter {
+	return &mockTokenSourceExpecter{m}
+}
+
+func (e *mockTokenSourceExpecter) Close() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Close",
+		file,
+		line)()
+}
+
+func (e *mockTokenSourceExpecter) next(timeout interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"next",
+		file,
+		line)(timeout)
+}
+
+// tokenSourceCloseReturns returns an action for mockTokenSource.Close
+// that returns the supplied values.
+func tokenSourceCloseReturns(o0 error) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// tokenSourceCloseDo returns an action for mockTokenSource.Close
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func tokenSourceCloseDo(f func() error) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// tokenSourceNextReturns returns an action for mockTokenSource.next
+// that returns the supplied values.
+func tokenSourceNextReturns(o0 *token, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// tokenSourceNextDo returns an action for mockTokenSource.next
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func tokenSourceNextDo(f func(time.Duration) (*token, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+type MockClock interface {
+	Clock
+	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockClockExpecter
+}
+
+type mockClock struct {
+	controller  oglemock.Controller
+	description string
+}
+
+func NewMockClock(
+	c oglemock.Controller,
+	desc string,
+	opts ...oglemock.MockOption) MockClock {
+	m := &mockClock{
+		controller:  c,
+		description: desc,
+	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
+}
+
+func (m *mockClock) Oglemock_Id() uintptr {
+	return uintptr(unsafe.Pointer(m))
+}
+
+func (m *mockClock) Oglemock_Description() string {
+	return m.description
+}
+
+func (m *mockClock) Now() (o0 time.Time) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"Now",
+		file,
+		line,
+		[]interface{}{})
+
+	if len(retVals) != 1 {
+		panic(fmt.Sprintf("mockClock.Now: invalid return values: %v", retVals))
+	}
+
+	// o0 time.Time
+	if retVals[0] != nil {
+		o0 = retVals[0].(time.Time)
+	}
+
+	return
+}
+
+func (m *mockClock) sleep(d time.Duration) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	// Hand the call off to the controller, which does most of the work.
+	retVals := m.controller.HandleMethodCall(
+		m,
+		"sleep",
+		file,
+		line,
+		[]interface{}{d})
+
+	if len(retVals) != 0 {
+		panic(fmt.Sprintf("mockClock.sleep: invalid return values: %v", retVals))
+	}
+
+	return
+}
+
+func (m *mockClock) Oglemock_MethodSignature(methodName string) interface{} {
+	switch methodName {
+
+	case "sleep":
+		return (func(time.Duration))(nil)
+
+	}
+
+	return nil
+}
+
+// MockClockExpecter sets up expectations for MockClock objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockClockExpecter struct {
+	m *mockClock
+}
+
+func (m *mockClock) EXPECT() *MockClockExpecter {
+	return &MockClockExpecter{m}
+}
+
+func (e *MockClockExpecter) Now() oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Now",
+		file,
+		line)()
+}
+
+func (e *MockClockExpecter) sleep(d interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"sleep",
+		file,
+		line)(d)
+}
+
+// ClockNowReturns returns an action for MockClock.Now
+// that returns the supplied values.
+func ClockNowReturns(o0 time.Time) oglemock.Action {
+	return oglemock.Return(o0)
+}
+
+// ClockNowDo returns an action for MockClock.Now
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ClockNowDo(f func() time.Time) oglemock.Action {
+	return oglemock.Invoke(f)
+}
+
+// ClockSleepReturns returns an action for MockClock.sleep
+// that returns the supplied values.
+func ClockSleepReturns() oglemock.Action {
+	return oglemock.Return()
+}
+
+// ClockSleepDo returns an action for MockClock.sleep
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ClockSleepDo(f func(time.Duration)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
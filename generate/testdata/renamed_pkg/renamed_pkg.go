@@ -20,5 +20,6 @@ package tony
 type SomeUint8Alias uint8
 
 type SomeInterface interface {
+	// DoFoo does foo with a.
 	DoFoo(a int) int
 }
@@ -0,0 +1,45 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// A package containing unexported interfaces and types, which can only be
+// mocked from within the package. Its name differs from its path.
+package hidden
+
+import (
+	"time"
+)
+
+type token struct {
+	value string
+}
+
+// An unexported interface whose methods refer to unexported types.
+type tokenSource interface {
+	// next returns the next token, waiting no longer than the supplied timeout.
+	next(timeout time.Duration) (*token, error)
+
+	Close() error
+}
+
+// An exported interface with an unexported method.
+type Clock interface {
+	Now() time.Time
+	sleep(d time.Duration)
+}
+
+// An exported interface whose methods refer to an unexported type.
+type TokenGetter interface {
+	Get(n int) ([]*token, error)
+}
@@ -28,9 +28,14 @@ import (
 //
 // For example, a pointer to an io.Reader may be rendered as "*Reader" or
 // "*io.Reader" depending on whether the package path is "io" or not.
+//
+// Types from other packages are qualified with the identifiers given in the
+// supplied map from package path to import identifier, or with their package
+// names for packages that aren't in the map.
 func typeString(
 	t reflect.Type,
-	pkgPath string) (s string) {
+	pkgPath string,
+	identifiers map[string]string) (s string) {
 	// Is this type named? If so we use its name, possibly with a package prefix.
 	//
 	// Examples:
@@ -43,6 +48,8 @@ func typeString(
 	if t.Name() != "" {
 		if t.PkgPath() == pkgPath {
 			s = t.Name()
+		} else if identifier, ok := identifiers[t.PkgPath()]; ok {
+			s = identifier + "." + t.Name()
 		} else {
 			s = t.String()
 		}
@@ -53,31 +60,37 @@ func typeString(
 	// This type is unnamed. Recurse.
 	switch t.Kind() {
 	case reflect.Array:
-		s = fmt.Sprintf("[%d]%s", t.Len(), typeString(t.Elem(), pkgPath))
+		s = fmt.Sprintf(
+			"[%d]%s",
+			t.Len(),
+			typeString(t.Elem(), pkgPath, identifiers))
 
 	case reflect.Chan:
-		s = fmt.Sprintf("%s %s", t.ChanDir(), typeString(t.Elem(), pkgPath))
+		s = fmt.Sprintf(
+			"%s %s",
+			t.ChanDir(),
+			typeString(t.Elem(), pkgPath, identifiers))
 
 	case reflect.Func:
-		s = typeString_Func(t, pkgPath)
+		s = typeString_Func(t, pkgPath, identifiers)
 
 	case reflect.Interface:
-		s = typeString_Interface(t, pkgPath)
+		s = typeString_Interface(t, pkgPath, identifiers)
 
 	case reflect.Map:
 		s = fmt.Sprintf(
 			"map[%s]%s",
-			typeString(t.Key(), pkgPath),
-			typeString(t.Elem(), pkgPath))
+			typeString(t.Key(), pkgPath, identifiers),
+			typeString(t.Elem(), pkgPath, identifiers))
 
 	case reflect.Ptr:
-		s = fmt.Sprintf("*%s", typeString(t.Elem(), pkgPath))
+		s = fmt.Sprintf("*%s", typeString(t.Elem(), pkgPath, identifiers))
 
 	case reflect.Slice:
-		s = fmt.Sprintf("[]%s", typeString(t.Elem(), pkgPath))
+		s = fmt.Sprintf("[]%s", typeString(t.Elem(), pkgPath, identifiers))
 
 	case reflect.Struct:
-		s = typeString_Struct(t, pkgPath)
+		s = typeString_Struct(t, pkgPath, identifiers)
 
 	default:
 		log.Panicf("Unhandled kind %v for type: %v", t.Kind(), t)
@@ -89,17 +102,24 @@ func typeString(
 func typeString_FuncOrMethod(
 	name string,
 	t reflect.Type,
-	pkgPath string) (s string) {
+	pkgPath string,
+	identifiers map[string]string) (s string) {
 	// Deal with input types.
 	var in []string
 	for i := 0; i < t.NumIn(); i++ {
-		in = append(in, typeString(t.In(i), pkgPath))
+		if i == t.NumIn()-1 && t.IsVariadic() {
+			elem := t.In(i).Elem()
+			in = append(in, "..."+typeString(elem, pkgPath, identifiers))
+			continue
+		}
+
+		in = append(in, typeString(t.In(i), pkgPath, identifiers))
 	}
 
 	// And output types.
 	var out []string
 	for i := 0; i < t.NumOut(); i++ {
-		out = append(out, typeString(t.Out(i), pkgPath))
+		out = append(out, typeString(t.Out(i), pkgPath, identifiers))
 	}
 
 	// Put it all together.
@@ -114,17 +134,23 @@ func typeString_FuncOrMethod(
 
 func typeString_Func(
 	t reflect.Type,
-	pkgPath string) (s string) {
-	return typeString_FuncOrMethod("func", t, pkgPath)
+	pkgPath string,
+	identifiers map[string]string) (s string) {
+	return typeString_FuncOrMethod("func", t, pkgPath, identifiers)
 }
 
 func typeString_Struct(
 	t reflect.Type,
-	pkgPath string) (s string) {
+	pkgPath string,
+	identifiers map[string]string) (s string) {
 	var fields []string
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
-		fString := fmt.Sprintf("%s %s", f.Name, typeString(f.Type, pkgPath))
+		fString := fmt.Sprintf(
+			"%s %s",
+			f.Name,
+			typeString(f.Type, pkgPath, identifiers))
+
 		fields = append(fields, fString)
 	}
 
@@ -134,11 +160,12 @@ func typeString_Struct(
 
 func typeString_Interface(
 	t reflect.Type,
-	pkgPath string) (s string) {
+	pkgPath string,
+	identifiers map[string]string) (s string) {
 	var methods []string
 	for i := 0; i < t.NumMethod(); i++ {
 		m := t.Method(i)
-		mString := typeString_FuncOrMethod(m.Name, m.Type, pkgPath)
+		mString := typeString_FuncOrMethod(m.Name, m.Type, pkgPath, identifiers)
 		methods = append(methods, mString)
 	}
 
@@ -209,12 +209,48 @@ func (t *TypeStringTest) TestCases() {
 
 		44: {to((*gcs.Int)(nil)), "some/pkg", "*gcs.Int"},
 		45: {to((*gcs.Int)(nil)), gcsPkgPath, "*Int"},
+
+		/////////////////////////
+		// Variadic functions
+		/////////////////////////
+
+		46: {
+			to(func(int, ...gcs.Object) {}),
+			gcsPkgPath,
+			"func(int, ...Object) ()",
+		},
+
+		47: {
+			to((*interface {
+				Foo(...int)
+			})(nil)).Elem(),
+			"some/pkg",
+			"interface { Foo(...int) () }",
+		},
 	}
 
 	for i, tc := range testCases {
 		ExpectEq(
 			tc.expected,
-			typeString(tc.t, tc.pkgPath),
+			typeString(tc.t, tc.pkgPath, nil),
 			"Case %d: %v, %q", i, tc.t, tc.pkgPath)
 	}
 }
+
+func (t *TypeStringTest) ImportIdentifiers() {
+	const gcsPkgPath = "github.com/jacobsa/oglemock/createmock/testdata/gcs"
+	identifiers := map[string]string{
+		gcsPkgPath: "gcs_2",
+		"io":       "io",
+	}
+
+	ft := reflect.TypeOf((func(io.Reader, map[string]*gcs.Object) gcs.Bucket)(nil))
+
+	ExpectEq(
+		"func(io.Reader, map[string]*gcs_2.Object) (gcs_2.Bucket)",
+		typeString(ft, "some/pkg", identifiers))
+
+	ExpectEq(
+		"func(io.Reader, map[string]*Object) (Bucket)",
+		typeString(ft, gcsPkgPath, identifiers))
+}
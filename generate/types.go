@@ -0,0 +1,411 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Like GenerateMockSource, but for interfaces described by the go/types
+// package rather than by reflection. This makes it possible to mock generic
+// interfaces, which reflection can't represent.
+//
+// Each type must be a named interface type. If it is a generic interface that
+// hasn't been instantiated, such as Store[K comparable, V any], the generated
+// mock is itself generic (MockStore[K, V]). If it is an instantiation, such as
+// Store[string, int], the generated mock is specific to it. As with
+// GenerateMockSource, the interfaces may come from different packages.
+//
+// The generated methods use the parameter and result names from the
+// interface's declaration where possible, and carry any doc comments found in
+// docs, which may be nil.
+func GenerateMockSourceFromTypes(
+	w io.Writer,
+	outputPkgPath string,
+	interfaces []*types.Named,
+	docs MethodDocs) (err error) {
+	// Sanity-check arguments.
+	if outputPkgPath == "" {
+		return errors.New("Package path must be non-empty.")
+	}
+
+	if len(interfaces) == 0 {
+		return errors.New("List of interfaces must be non-empty.")
+	}
+
+	// Make sure each type is indeed an interface, and that anything unexported
+	// is only mocked within its own package.
+	for _, it := range interfaces {
+		if !types.IsInterface(it) {
+			return errors.New("Invalid type: " + it.String())
+		}
+
+		if err = checkUnexported(it, outputPkgPath); err != nil {
+			return
+		}
+	}
+
+	// Find the packages that the rendered types refer to, by rendering them
+	// once and discarding the result.
+	pkgNames := make(map[string]string)
+	collect := func(p *types.Package) string {
+		pkgNames[p.Path()] = p.Name()
+		return p.Name()
+	}
+
+	for _, it := range interfaces {
+		describeTypesInterface(it, collect, docs)
+	}
+
+	// Choose identifiers for them, then render the types for real.
+	arg := tmplArg{
+		OutputPkgPath: outputPkgPath,
+		OutputPkgName: path.Base(outputPkgPath),
+		Imports:       assignImportIdentifiers(pkgNames, outputPkgPath),
+	}
+
+	// When generating code for a package that we've seen, use its real name,
+	// which may differ from the last element of its path.
+	if name, ok := pkgNames[outputPkgPath]; ok {
+		arg.OutputPkgName = name
+	}
+
+	identifiers := arg.Imports.identifiers()
+	qualifier := func(p *types.Package) string {
+		if p.Path() == outputPkgPath {
+			return ""
+		}
+
+		return identifiers[p.Path()]
+	}
+
+	for _, it := range interfaces {
+		arg.Interfaces = append(
+			arg.Interfaces,
+			describeTypesInterface(it, qualifier, docs))
+	}
+
+	return writeMockSource(w, arg)
+}
+
+// Return an error if the supplied interface, any of its methods, or any type
+// its methods refer to is unexported and declared outside of the package with
+// the given path. Such interfaces can't be implemented there.
+func checkUnexported(it *types.Named, outputPkgPath string) error {
+	if obj := it.Obj(); !obj.Exported() && obj.Pkg().Path() != outputPkgPath {
+		return fmt.Errorf(
+			"Unexported interface %s can only be mocked in package %s",
+			obj.Name(),
+			obj.Pkg().Path())
+	}
+
+	iface := it.Underlying().(*types.Interface)
+	for i := 0; i < iface.NumMethods(); i++ {
+		f := iface.Method(i)
+		if !f.Exported() && f.Pkg().Path() != outputPkgPath {
+			return fmt.Errorf(
+				"Interface %s has unexported method %s, so can only be mocked in "+
+					"package %s",
+				it.Obj().Name(),
+				f.Name(),
+				f.Pkg().Path())
+		}
+
+		visited := make(map[types.Type]bool)
+		if obj := findUnexportedType(f.Type(), outputPkgPath, visited); obj != nil {
+			return fmt.Errorf(
+				"Interface %s has method %s referring to unexported type %s.%s, so "+
+					"can only be mocked in package %s",
+				it.Obj().Name(),
+				f.Name(),
+				obj.Pkg().Name(),
+				obj.Name(),
+				obj.Pkg().Path())
+		}
+	}
+
+	return nil
+}
+
+// Return an unexported named type, declared outside of the package with the
+// given path, to which the supplied type refers, or nil if there is none.
+func findUnexportedType(
+	t types.Type,
+	outputPkgPath string,
+	visited map[types.Type]bool) *types.TypeName {
+	if visited[t] {
+		return nil
+	}
+
+	visited[t] = true
+
+	find := func(t types.Type) *types.TypeName {
+		return findUnexportedType(t, outputPkgPath, visited)
+	}
+
+	findInTuple := func(tuple *types.Tuple) *types.TypeName {
+		for i := 0; i < tuple.Len(); i++ {
+			if obj := find(tuple.At(i).Type()); obj != nil {
+				return obj
+			}
+		}
+
+		return nil
+	}
+
+	isForeignUnexported := func(obj *types.TypeName) bool {
+		return obj.Pkg() != nil &&
+			!obj.Exported() &&
+			obj.Pkg().Path() != outputPkgPath
+	}
+
+	switch t := t.(type) {
+	case *types.Alias:
+		if isForeignUnexported(t.Obj()) {
+			return t.Obj()
+		}
+
+		return find(types.Unalias(t))
+
+	case *types.Named:
+		if isForeignUnexported(t.Obj()) {
+			return t.Obj()
+		}
+
+		// The underlying type of a named type doesn't appear in the generated
+		// code, but any type arguments do.
+		args := t.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			if obj := find(args.At(i)); obj != nil {
+				return obj
+			}
+		}
+
+	case *types.Pointer:
+		return find(t.Elem())
+
+	case *types.Slice:
+		return find(t.Elem())
+
+	case *types.Array:
+		return find(t.Elem())
+
+	case *types.Chan:
+		return find(t.Elem())
+
+	case *types.Map:
+		if obj := find(t.Key()); obj != nil {
+			return obj
+		}
+
+		return find(t.Elem())
+
+	case *types.Signature:
+		if obj := findInTuple(t.Params()); obj != nil {
+			return obj
+		}
+
+		return findInTuple(t.Results())
+
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if obj := find(t.Field(i).Type()); obj != nil {
+				return obj
+			}
+		}
+
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			if obj := find(t.Method(i).Type()); obj != nil {
+				return obj
+			}
+		}
+	}
+
+	return nil
+}
+
+// Describe the supplied named interface type for the template, rendering types
+// using the given qualifier.
+func describeTypesInterface(
+	it *types.Named,
+	qualifier types.Qualifier,
+	docs MethodDocs) (desc mockedInterface) {
+	desc.Name = it.Obj().Name()
+
+	// For an uninstantiated generic interface, the mock takes the same type
+	// parameters. types.TypeString would render the parameters along with
+	// their constraints, so build the reference by hand.
+	if tps := it.TypeParams(); tps.Len() > 0 && it.TypeArgs().Len() == 0 {
+		var params, args []string
+		for i := 0; i < tps.Len(); i++ {
+			tp := tps.At(i)
+			params = append(
+				params,
+				tp.Obj().Name()+" "+types.TypeString(tp.Constraint(), qualifier))
+
+			args = append(args, tp.Obj().Name())
+		}
+
+		desc.TypeParams = "[" + strings.Join(params, ", ") + "]"
+		desc.TypeArgs = "[" + strings.Join(args, ", ") + "]"
+
+		desc.TypeString = desc.Name + desc.TypeArgs
+		if q := qualifier(it.Obj().Pkg()); q != "" {
+			desc.TypeString = q + "." + desc.TypeString
+		}
+	} else {
+		desc.TypeString = types.TypeString(it, qualifier)
+	}
+
+	// The methods of the underlying interface are sorted by name, and any type
+	// arguments have already been substituted.
+	iface := it.Underlying().(*types.Interface)
+	for i := 0; i < iface.NumMethods(); i++ {
+		f := iface.Method(i)
+		md := describeTypesMethod(
+			f.Name(),
+			f.Type().(*types.Signature),
+			qualifier,
+			desc.TypeParams)
+
+		md.Doc = docs[f.Pos()]
+		desc.Methods = append(desc.Methods, md)
+	}
+
+	return
+}
+
+// Identifiers that the generated code for a method refers to or declares
+// itself, and that therefore can't be used as parameter or result names.
+var reservedNames = []string{
+	// Receivers and parameters.
+	"e",
+	"f",
+	"m",
+
+	// Local variables.
+	"file",
+	"line",
+	"retVals",
+
+	// Imports.
+	"fmt",
+	"oglemock",
+	"runtime",
+	"unsafe",
+}
+
+var identifierRegexp = regexp.MustCompile(`[\pL_][\pL\p{Nd}_]*`)
+
+func describeTypesMethod(
+	name string,
+	sig *types.Signature,
+	qualifier types.Qualifier,
+	typeParams string) (md mockedMethod) {
+	md.Name = name
+	md.Variadic = sig.Variadic()
+
+	params := sig.Params()
+	for i := 0; i < params.Len(); i++ {
+		t := params.At(i).Type()
+		if i == params.Len()-1 && sig.Variadic() {
+			elem := t.(*types.Slice).Elem()
+			md.Inputs = append(md.Inputs, "..."+types.TypeString(elem, qualifier))
+			continue
+		}
+
+		md.Inputs = append(md.Inputs, types.TypeString(t, qualifier))
+	}
+
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		md.Outputs = append(
+			md.Outputs,
+			types.TypeString(results.At(i).Type(), qualifier))
+	}
+
+	// Render the signature without parameter names, in the same form that
+	// typeString uses for function types.
+	md.FuncType = fmt.Sprintf(
+		"func(%s) (%s)",
+		strings.Join(md.Inputs, ", "),
+		strings.Join(md.Outputs, ", "))
+
+	// Choose names. A declared name can't be used if it would shadow or clash
+	// with anything else the generated code refers to, including the package
+	// names and type names that appear in the signature.
+	used := make(map[string]bool)
+	for _, n := range reservedNames {
+		used[n] = true
+	}
+
+	for _, t := range append(append([]string{typeParams}, md.Inputs...), md.Outputs...) {
+		for _, n := range identifierRegexp.FindAllString(t, -1) {
+			used[n] = true
+		}
+	}
+
+	md.InputNames = chooseNames(params, used)
+	md.OutputNames = chooseNames(results, used)
+	fillNames(md.InputNames, "p", used)
+	fillNames(md.OutputNames, "o", used)
+
+	return
+}
+
+// Return the declared names of the supplied variables, or the empty string
+// for those whose names can't be used, marking the names returned as used.
+func chooseNames(vars *types.Tuple, used map[string]bool) (names []string) {
+	for i := 0; i < vars.Len(); i++ {
+		n := vars.At(i).Name()
+		if n == "_" || used[n] || types.Universe.Lookup(n) != nil {
+			n = ""
+		}
+
+		if n != "" {
+			used[n] = true
+		}
+
+		names = append(names, n)
+	}
+
+	return
+}
+
+// Replace any empty names with the supplied prefix and the variable's index,
+// adding underscores as necessary to avoid names that are already used.
+func fillNames(names []string, prefix string, used map[string]bool) {
+	for i, n := range names {
+		if n != "" {
+			continue
+		}
+
+		n = fmt.Sprintf("%s%d", prefix, i)
+		for used[n] {
+			n += "_"
+		}
+
+		used[n] = true
+		names[i] = n
+	}
+}
@@ -20,6 +20,7 @@ import (
 	. "github.com/jacobsa/oglematchers"
 	"github.com/jacobsa/oglemock"
 	"github.com/jacobsa/oglemock/sample/mock_io"
+	"github.com/jacobsa/oglemock/sample/mock_store"
 	. "github.com/jacobsa/ogletest"
 	"path"
 	"runtime"
@@ -127,3 +128,108 @@ func (t *IntegrationTest) WrongTypeForReturn() {
 	ExpectThat(r.err, Error(HasSubstr("int")))
 	ExpectThat(r.err, Error(HasSubstr("string")))
 }
+
+func (t *IntegrationTest) NiceMockReader() {
+	reader := mock_io.NewMockReader(
+		t.controller,
+		"",
+		oglemock.WithMode(oglemock.Nice))
+
+	n, err := reader.Read([]uint8{1, 2, 3})
+	ExpectEq(0, n)
+	ExpectEq(nil, err)
+
+	AssertEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	AssertEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *IntegrationTest) TypedExpectations() {
+	// Expectations
+	t.reader.EXPECT().Read(nil).
+		WillOnce(oglemock.Return(17, nil))
+
+	t.reader.EXPECT().Read(Not(Equals(nil))).
+		WillOnce(oglemock.Return(23, errors.New("taco")))
+
+	// Calls
+	var n int
+	var err error
+
+	n, err = t.reader.Read([]byte{})
+	ExpectEq(23, n)
+	ExpectThat(err, Error(Equals("taco")))
+
+	n, err = t.reader.Read(nil)
+	ExpectEq(17, n)
+	ExpectEq(nil, err)
+
+	// Errors
+	AssertEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	AssertEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *IntegrationTest) TypedExpectationRecordsCallerLocation() {
+	t.reader.EXPECT().Read(nil)
+	expectedLine := getLineNumber() - 1
+
+	t.controller.Finish()
+
+	AssertEq(1, len(t.reporter.errors), "%v", t.reporter.errors)
+
+	r := t.reporter.errors[0]
+	ExpectEq("integration_test.go", path.Base(r.fileName))
+	ExpectEq(expectedLine, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("Unsatisfied")))
+}
+
+func (t *IntegrationTest) TypedActions() {
+	// Expectations
+	t.reader.EXPECT().Read(nil).
+		WillOnce(mock_io.ReaderReadReturns(17, nil))
+
+	t.reader.EXPECT().Read(Not(Equals(nil))).
+		WillOnce(mock_io.ReaderReadDo(func(p []byte) (int, error) {
+			return len(p), errors.New("taco")
+		}))
+
+	// Calls
+	var n int
+	var err error
+
+	n, err = t.reader.Read(make([]byte, 4))
+	ExpectEq(4, n)
+	ExpectThat(err, Error(Equals("taco")))
+
+	n, err = t.reader.Read(nil)
+	ExpectEq(17, n)
+	ExpectEq(nil, err)
+
+	// Errors
+	AssertEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	AssertEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
+
+func (t *IntegrationTest) GenericMock() {
+	store := mock_store.NewMockStore[string, int](t.controller, "store")
+
+	// Expectations
+	store.EXPECT().Get("taco").
+		WillOnce(mock_store.StoreGetReturns[string](17, true))
+
+	store.EXPECT().Put(HasSubstr("burrito"), 19).
+		WillOnce(mock_store.StorePutDo(func(k string, v int) error {
+			return errors.New(k)
+		}))
+
+	// Calls
+	v, ok := store.Get("taco")
+	ExpectEq(17, v)
+	ExpectTrue(ok)
+
+	err := store.Put("burrito bowl", 19)
+	ExpectThat(err, Error(Equals("burrito bowl")))
+
+	// Errors
+	AssertEq(0, len(t.reporter.errors), "%v", t.reporter.errors)
+	AssertEq(0, len(t.reporter.fatalErrors), "%v", t.reporter.fatalErrors)
+}
@@ -16,11 +16,13 @@
 package oglemock
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/jacobsa/oglematchers"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // InternalExpectation is exported for purposes of testing only. You should not
@@ -54,6 +56,10 @@ type InternalExpectation struct {
 	// listed by the user. If there was no explicit number expressed, this is -1.
 	ExpectedNumMatches int
 
+	// The range of the number of times this expectation should be matched, as
+	// explicitly set by the user with Cardinality, or nil if there is none.
+	ExplicitCardinality *Cardinality
+
 	// Actions to be taken for the first N calls, one per call in order, where N
 	// is the length of this slice.
 	OneTimeActions []Action
@@ -62,8 +68,34 @@ type InternalExpectation struct {
 	// there is no such action.
 	FallbackAction Action
 
+	// Expectations that must be satisfied before this one may be matched, as
+	// configured with InSequence. Each is the expectation that preceded this one
+	// in some sequence.
+	Prerequisites []*InternalExpectation
+
+	// An expectation following this one in some sequence that has been matched,
+	// or nil if there is none. Once it is set, calls matching this expectation
+	// are out of order.
+	Successor *InternalExpectation
+
+	// The sequences to which the expectation has been added with InSequence.
+	sequences []*Sequence
+
+	// Whether the expectation should be retired once it is saturated, as
+	// configured with RetiresOnSaturation.
+	RetireOnSaturation bool
+
 	// The number of times this expectation has been matched so far.
 	NumMatches uint
+
+	// Whether the expectation has been retired, and so should no longer match
+	// calls.
+	Retired bool
+
+	// A channel that is closed and discarded each time NumMatches changes, for
+	// the benefit of goroutines waiting for the expectation to be satisfied. It
+	// is created lazily, and is nil when nobody is waiting.
+	matchesChanged chan struct{}
 }
 
 // InternalNewExpectation is exported for purposes of testing only. You should
@@ -86,18 +118,25 @@ func InternalNewExpectation(
 	result.ExpectedNumMatches = -1
 	result.OneTimeActions = make([]Action, 0)
 
-	// Set up the ArgMatchers slice, using Equals(x) for each x that is not a
-	// matcher itself.
-	result.ArgMatchers = make([]oglematchers.Matcher, len(args))
+	// Set up the ArgMatchers slice.
+	result.ArgMatchers = makeArgMatchers(args)
+
+	return result
+}
+
+// Convert a list of expected arguments to matchers, using Equals(x) for each x
+// that is not a matcher itself.
+func makeArgMatchers(args []interface{}) []oglematchers.Matcher {
+	matchers := make([]oglematchers.Matcher, len(args))
 	for i, x := range args {
 		if matcher, ok := x.(oglematchers.Matcher); ok {
-			result.ArgMatchers[i] = matcher
+			matchers[i] = matcher
 		} else {
-			result.ArgMatchers[i] = oglematchers.Equals(x)
+			matchers[i] = oglematchers.Equals(x)
 		}
 	}
 
-	return result
+	return matchers
 }
 
 func (e *InternalExpectation) Times(n uint) Expectation {
@@ -110,6 +149,11 @@ func (e *InternalExpectation) Times(n uint) Expectation {
 		return nil
 	}
 
+	if e.ExplicitCardinality != nil {
+		e.reportFatalError("Times called after Cardinality.")
+		return nil
+	}
+
 	// It is illegal to call this after any actions are configured.
 	if len(e.OneTimeActions) != 0 {
 		e.reportFatalError("Times called after WillOnce.")
@@ -131,6 +175,42 @@ func (e *InternalExpectation) Times(n uint) Expectation {
 	return e
 }
 
+func (e *InternalExpectation) Cardinality(c Cardinality) Expectation {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	// It is illegal to call this more than once, or after Times.
+	if e.ExplicitCardinality != nil {
+		e.reportFatalError("Cardinality called more than once.")
+		return nil
+	}
+
+	if e.ExpectedNumMatches != -1 {
+		e.reportFatalError("Cardinality called after Times.")
+		return nil
+	}
+
+	// It is illegal to call this after any actions are configured.
+	if len(e.OneTimeActions) != 0 {
+		e.reportFatalError("Cardinality called after WillOnce.")
+		return nil
+	}
+
+	if e.FallbackAction != nil {
+		e.reportFatalError("Cardinality called after WillRepeatedly.")
+		return nil
+	}
+
+	// Make sure the range is non-empty.
+	if c.min > c.max {
+		e.reportFatalError(fmt.Sprintf("Cardinality given empty range: %v", c))
+		return nil
+	}
+
+	e.ExplicitCardinality = &c
+	return e
+}
+
 func (e *InternalExpectation) WillOnce(a Action) Expectation {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
@@ -175,6 +255,89 @@ func (e *InternalExpectation) WillRepeatedly(a Action) Expectation {
 	return e
 }
 
+func (e *InternalExpectation) InSequence(seqs ...*Sequence) Expectation {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, s := range seqs {
+		if s == nil {
+			e.reportFatalError("InSequence given a nil sequence.")
+			return nil
+		}
+
+		// Adding the expectation to the same sequence twice must not make it its
+		// own prerequisite.
+		prev := s.addExpectation(e)
+		if prev == e {
+			continue
+		}
+
+		if prev != nil {
+			e.Prerequisites = append(e.Prerequisites, prev)
+		}
+
+		e.sequences = append(e.sequences, s)
+	}
+
+	return e
+}
+
+func (e *InternalExpectation) RetiresOnSaturation() Expectation {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.RetireOnSaturation = true
+	return e
+}
+
+func (e *InternalExpectation) Wait(timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return e.waitForSatisfied(ctx)
+}
+
+// Record a match against the expectation, waking up anybody waiting for it to
+// be satisfied.
+//
+// e.mutex must be held.
+func (e *InternalExpectation) recordMatchLocked() {
+	e.NumMatches++
+
+	if e.matchesChanged != nil {
+		close(e.matchesChanged)
+		e.matchesChanged = nil
+	}
+}
+
+// Block until the minimum cardinality of the expectation has been satisfied,
+// returning true, or until the context is cancelled, returning false.
+//
+// e.mutex must not be held.
+func (e *InternalExpectation) waitForSatisfied(ctx context.Context) bool {
+	for {
+		e.mutex.Lock()
+		minCardinality, _ := computeCardinalityLocked(e)
+		if e.NumMatches >= minCardinality {
+			e.mutex.Unlock()
+			return true
+		}
+
+		if e.matchesChanged == nil {
+			e.matchesChanged = make(chan struct{})
+		}
+
+		changed := e.matchesChanged
+		e.mutex.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
 func (e *InternalExpectation) reportFatalError(errorText string) {
 	e.errorReporter.ReportFatalError(e.FileName, e.LineNumber, errors.New(errorText))
 }
@@ -263,3 +263,118 @@ func (t *InternalExpectationTest) FallbackActionRejectsSignature() {
 	ExpectThat(r.err, Error(HasSubstr("expected float64")))
 	ExpectThat(r.err, Error(HasSubstr("given string")))
 }
+
+func (t *InternalExpectationTest) InSequence() {
+	seq0 := NewSequence()
+	seq1 := NewSequence()
+
+	exp0 := t.makeExpectation(emptyReturnSig, []interface{}{}, "", 0)
+	exp1 := t.makeExpectation(emptyReturnSig, []interface{}{}, "", 0)
+	exp2 := t.makeExpectation(emptyReturnSig, []interface{}{}, "", 0)
+
+	exp0.InSequence(seq0)
+	exp1.InSequence(seq1)
+	exp2.InSequence(seq0, seq1).InSequence(seq0)
+
+	ExpectThat(len(exp0.Prerequisites), Equals(0))
+	ExpectThat(len(exp1.Prerequisites), Equals(0))
+
+	AssertThat(len(exp2.Prerequisites), Equals(2))
+	ExpectEq(exp0, exp2.Prerequisites[0])
+	ExpectEq(exp1, exp2.Prerequisites[1])
+}
+
+func (t *InternalExpectationTest) InSequenceGivenNilSequence() {
+	exp := t.makeExpectation(emptyReturnSig, []interface{}{}, "taco.go", 112)
+	exp.InSequence(NewSequence(), nil)
+
+	AssertEq(1, len(t.reporter.fatalErrors))
+	AssertEq(0, len(t.reporter.errors))
+
+	r := t.reporter.fatalErrors[0]
+	ExpectEq("taco.go", r.fileName)
+	ExpectEq(112, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("InSequence")))
+	ExpectThat(r.err, Error(HasSubstr("nil")))
+}
+
+func (t *InternalExpectationTest) ExplicitCardinality() {
+	exp := t.makeExpectation(emptyReturnSig, []interface{}{}, "", 0)
+	ExpectEq(nil, exp.ExplicitCardinality)
+
+	exp.Cardinality(AtLeast(3))
+
+	AssertNe(nil, exp.ExplicitCardinality)
+	ExpectEq("at least 3 times", exp.ExplicitCardinality.String())
+	ExpectThat(exp.ExpectedNumMatches, Equals(-1))
+}
+
+func (t *InternalExpectationTest) CardinalityCalledTwice() {
+	exp := t.makeExpectation(emptyReturnSig, []interface{}{}, "taco.go", 112)
+	exp.Cardinality(AtLeast(3))
+	exp.Cardinality(AtMost(3))
+
+	AssertEq(1, len(t.reporter.fatalErrors))
+	AssertEq(0, len(t.reporter.errors))
+
+	r := t.reporter.fatalErrors[0]
+	ExpectEq("taco.go", r.fileName)
+	ExpectEq(112, r.lineNumber)
+	ExpectThat(r.err, Error(HasSubstr("Cardinality")))
+	ExpectThat(r.err, Error(HasSubstr("more than once")))
+}
+
+func (t *InternalExpectationTest) CardinalityAndTimes() {
+	exp0 := t.makeExpectation(emptyReturnSig, []interface{}{}, "taco.go", 112)
+	exp0.Times(3)
+	exp0.Cardinality(AtMost(3))
+
+	exp1 := t.makeExpectation(emptyReturnSig, []interface{}{}, "taco.go", 112)
+	exp1.Cardinality(AtMost(3))
+	exp1.Times(3)
+
+	AssertEq(2, len(t.reporter.fatalErrors))
+	AssertEq(0, len(t.reporter.errors))
+
+	ExpectThat(
+		t.reporter.fatalErrors[0].err,
+		Error(HasSubstr("Cardinality called after Times")))
+
+	ExpectThat(
+		t.reporter.fatalErrors[1].err,
+		Error(HasSubstr("Times called after Cardinality")))
+}
+
+func (t *InternalExpectationTest) CardinalityCalledAfterWillOnce() {
+	exp := t.makeExpectation(emptyReturnSig, []interface{}{}, "taco.go", 112)
+	exp.WillOnce(Return())
+	exp.Cardinality(AtLeast(1))
+
+	AssertEq(1, len(t.reporter.fatalErrors))
+	AssertEq(0, len(t.reporter.errors))
+
+	r := t.reporter.fatalErrors[0]
+	ExpectThat(r.err, Error(HasSubstr("Cardinality")))
+	ExpectThat(r.err, Error(HasSubstr("after WillOnce")))
+}
+
+func (t *InternalExpectationTest) CardinalityGivenEmptyRange() {
+	exp := t.makeExpectation(emptyReturnSig, []interface{}{}, "taco.go", 112)
+	exp.Cardinality(Between(3, 2))
+
+	AssertEq(1, len(t.reporter.fatalErrors))
+	AssertEq(0, len(t.reporter.errors))
+
+	r := t.reporter.fatalErrors[0]
+	ExpectThat(r.err, Error(HasSubstr("Cardinality")))
+	ExpectThat(r.err, Error(HasSubstr("empty range")))
+}
+
+func (t *InternalExpectationTest) RetiresOnSaturation() {
+	exp := t.makeExpectation(emptyReturnSig, []interface{}{}, "", 0)
+	ExpectFalse(exp.RetireOnSaturation)
+
+	exp.RetiresOnSaturation()
+	ExpectTrue(exp.RetireOnSaturation)
+	ExpectFalse(exp.Retired)
+}
@@ -23,7 +23,8 @@ import (
 
 // Create an Action that invokes the supplied function, returning whatever it
 // returns. The signature of the function must match that of the mocked method
-// exactly.
+// exactly. In particular, if the method is variadic then so must be the
+// function; it receives the same variadic arguments as the method.
 func Invoke(f interface{}) Action {
 	// Make sure f is a function.
 	fv := reflect.ValueOf(f)
@@ -56,14 +57,26 @@ func (a *invokeAction) SetSignature(signature reflect.Type) error {
 }
 
 func (a *invokeAction) Invoke(vals []interface{}) []interface{} {
-	// Create a slice of args for the function.
+	// Create a slice of args for the function. Nil interface values must be
+	// converted to zero values of the appropriate type.
+	ft := a.f.Type()
 	in := make([]reflect.Value, len(vals))
 	for i, x := range vals {
 		in[i] = reflect.ValueOf(x)
+		if !in[i].IsValid() {
+			in[i] = reflect.Zero(ft.In(i))
+		}
+	}
+
+	// Call the function and return its return values. The arguments for a
+	// variadic parameter are already packaged up in a slice.
+	var out []reflect.Value
+	if ft.IsVariadic() {
+		out = a.f.CallSlice(in)
+	} else {
+		out = a.f.Call(in)
 	}
 
-	// Call the function and return its return values.
-	out := a.f.Call(in)
 	result := make([]interface{}, len(out))
 	for i, v := range out {
 		result[i] = v.Interface()
@@ -16,6 +16,7 @@
 package oglemock_test
 
 import (
+	"errors"
 	. "github.com/jacobsa/oglematchers"
 	"github.com/jacobsa/oglemock"
 	. "github.com/jacobsa/ogletest"
@@ -108,3 +109,45 @@ func (t *InvokeTest) ReturnsFunctionResult() {
 			IdenticalTo(expectedReturn0),
 			IdenticalTo(expectedReturn1)))
 }
+
+func (t *InvokeTest) CallsVariadicFunction() {
+	var actualFormat string
+	var actualArgs []interface{}
+
+	f := func(format string, args ...interface{}) int {
+		actualFormat = format
+		actualArgs = args
+		return len(args)
+	}
+
+	a := oglemock.Invoke(f)
+
+	// Set signature.
+	AssertEq(nil, a.SetSignature(reflect.TypeOf(f)))
+
+	// Call the action, passing the variadic arguments as a slice in the same
+	// way that mock implementations do.
+	res := a.Invoke([]interface{}{"taco", []interface{}{17, "burrito"}})
+
+	ExpectEq("taco", actualFormat)
+	ExpectThat(actualArgs, ElementsAre(17, "burrito"))
+	ExpectThat(res, ElementsAre(2))
+}
+
+func (t *InvokeTest) NilInterfaceArgument() {
+	var actualArg error = errors.New("")
+
+	f := func(err error) {
+		actualArg = err
+	}
+
+	a := oglemock.Invoke(f)
+
+	// Set signature.
+	AssertEq(nil, a.SetSignature(reflect.TypeOf(f)))
+
+	// Call the action.
+	a.Invoke([]interface{}{nil})
+
+	ExpectEq(nil, actualArg)
+}
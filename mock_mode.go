@@ -0,0 +1,54 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock
+
+// MockMode controls how a controller treats "uninteresting" calls to a mock
+// object, i.e. calls to a method for which no expectations have been
+// registered on that object. Calls to a method that has expectations but that
+// match none of them are always reported as errors, regardless of mode.
+//
+// In every mode, an uninteresting call invokes the matching default action
+// configured with Controller.OnCall, if any, and otherwise returns zero values.
+type MockMode int
+
+const (
+	// Uninteresting calls are reported as errors. This is the default.
+	Strict MockMode = iota
+
+	// Uninteresting calls are reported as warnings (see WarningReporter).
+	Naggy
+
+	// Uninteresting calls are silently allowed.
+	Nice
+)
+
+// MockOption configures a mock object at the time it is created. Generated
+// mock constructors accept a list of options, for example:
+//
+//     bucket := mock_gcs.NewMockBucket(
+//         controller,
+//         "bucket",
+//         oglemock.WithMode(oglemock.Nice))
+//
+type MockOption func(c Controller, o MockObject)
+
+// WithMode returns an option that sets the mode of the mock object being
+// created. See Controller.SetMode.
+func WithMode(mode MockMode) MockOption {
+	return func(c Controller, o MockObject) {
+		c.SetMode(o, mode)
+	}
+}
@@ -28,3 +28,13 @@ type MockObject interface {
 	// helpful in test failure messages.
 	Oglemock_Description() string
 }
+
+// Reflection can't find unexported methods, so mock objects for interfaces
+// with unexported methods additionally implement this interface, which the
+// controller uses to find the signatures of those methods.
+type unexportedMethodSignaturer interface {
+	// Oglemock_MethodSignature returns a nil function value whose type is the
+	// signature of the named unexported method, or nil if there is no such
+	// method.
+	Oglemock_MethodSignature(methodName string) interface{}
+}
@@ -0,0 +1,114 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/jacobsa/oglematchers"
+)
+
+// PartialOnCall is a function that should be called exactly once with
+// expected arguments or matchers in order to set up a default behavior for
+// matching method calls. See Controller.OnCall. It returns a DefaultBehavior
+// whose action should be configured with WillByDefault.
+//
+// If the arguments are of the wrong type, the function reports a fatal error
+// and returns nil.
+type PartialOnCall func(...interface{}) DefaultBehavior
+
+// DefaultBehavior describes what a mock method should do when called with
+// particular arguments, without expressing any expectation about whether or
+// how many times it will be called.
+type DefaultBehavior interface {
+	// WillByDefault configures the action to be invoked for matching calls that
+	// don't match any expectation, or that match an expectation that has no
+	// action of its own to invoke. WillByDefault must be called exactly once.
+	WillByDefault(a Action)
+}
+
+// A default behavior registered with Controller.OnCall.
+type onCallSpec struct {
+	// The signature of the method to which this behavior is bound, for checking
+	// action types.
+	methodSignature reflect.Type
+
+	// An error reporter to use for reporting errors in the way that the behavior
+	// is set up.
+	errorReporter ErrorReporter
+
+	// Matchers that the arguments to the mock method must satisfy in order for
+	// the behavior to apply.
+	argMatchers []oglematchers.Matcher
+
+	// The file name and line number at which the behavior was set up.
+	fileName   string
+	lineNumber int
+
+	mutex sync.Mutex
+
+	// The action configured by WillByDefault, or nil if none has been.
+	action Action // Protected by mutex
+}
+
+func newOnCallSpec(
+	reporter ErrorReporter,
+	methodSignature reflect.Type,
+	args []interface{},
+	fileName string,
+	lineNumber int) *onCallSpec {
+	return &onCallSpec{
+		methodSignature: methodSignature,
+		errorReporter:   reporter,
+		argMatchers:     makeArgMatchers(args),
+		fileName:        fileName,
+		lineNumber:      lineNumber,
+	}
+}
+
+func (s *onCallSpec) WillByDefault(a Action) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// It is illegal to call this twice.
+	if s.action != nil {
+		s.reportFatalError("WillByDefault called more than once.")
+		return
+	}
+
+	// Tell the action about the method's signature.
+	if err := a.SetSignature(s.methodSignature); err != nil {
+		s.reportFatalError(fmt.Sprintf("WillByDefault given invalid action: %v", err))
+		return
+	}
+
+	s.action = a
+}
+
+// Return the configured action, or nil if none has been configured.
+func (s *onCallSpec) getAction() Action {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.action
+}
+
+func (s *onCallSpec) reportFatalError(errorText string) {
+	s.errorReporter.ReportFatalError(s.fileName, s.lineNumber, errors.New(errorText))
+}
@@ -17,6 +17,10 @@ import (
 type MockReader interface {
 	io.Reader
 	oglemock.MockObject
+
+	// EXPECT returns a helper for setting up expectations on the mock object
+	// with compile-time checking of method names and argument counts.
+	EXPECT() *MockReaderExpecter
 }
 
 type mockReader struct {
@@ -26,11 +30,18 @@ type mockReader struct {
 
 func NewMockReader(
 	c oglemock.Controller,
-	desc string) MockReader {
-	return &mockReader{
+	desc string,
+	opts ...oglemock.MockOption) MockReader {
+	m := &mockReader{
 		controller:  c,
 		description: desc,
 	}
+
+	for _, opt := range opts {
+		opt(c, m)
+	}
+
+	return m
 }
 
 func (m *mockReader) Oglemock_Id() uintptr {
@@ -42,6 +53,9 @@ func (m *mockReader) Oglemock_Description() string {
 }
 
 func (m *mockReader) Read(p0 []uint8) (o0 int, o1 error) {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(m.controller)()
+
 	// Get a file name and line number for the caller.
 	_, file, line, _ := runtime.Caller(1)
 
@@ -69,3 +83,41 @@ func (m *mockReader) Read(p0 []uint8) (o0 int, o1 error) {
 
 	return
 }
+
+// MockReaderExpecter sets up expectations for MockReader objects. Each
+// argument to its methods may be a value or an oglematchers.Matcher, as with
+// oglemock.Controller.ExpectCall.
+type MockReaderExpecter struct {
+	m *mockReader
+}
+
+func (m *mockReader) EXPECT() *MockReaderExpecter {
+	return &MockReaderExpecter{m}
+}
+
+func (e *MockReaderExpecter) Read(p0 interface{}) oglemock.Expectation {
+	// Attribute any failures to the caller.
+	oglemock.HelperFunc(e.m.controller)()
+
+	// Get a file name and line number for the caller.
+	_, file, line, _ := runtime.Caller(1)
+
+	return e.m.controller.ExpectCall(
+		e.m,
+		"Read",
+		file,
+		line)(p0)
+}
+
+// ReaderReadReturns returns an action for MockReader.Read
+// that returns the supplied values.
+func ReaderReadReturns(o0 int, o1 error) oglemock.Action {
+	return oglemock.Return(o0, o1)
+}
+
+// ReaderReadDo returns an action for MockReader.Read
+// that invokes the supplied function with the call's arguments and returns
+// its results.
+func ReaderReadDo(f func([]uint8) (int, error)) oglemock.Action {
+	return oglemock.Invoke(f)
+}
@@ -22,7 +22,8 @@ import (
 
 // Create an Action that saves the argument at the given zero-based index to
 // the supplied destination, which must be a pointer to a type that is
-// assignable from the argument type.
+// assignable from the argument type. For the variadic parameter of a variadic
+// method, the argument is the slice of all variadic arguments.
 func SaveArg(index int, dst interface{}) Action {
 	return &saveArg{
 		index:      index,
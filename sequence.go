@@ -0,0 +1,83 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock
+
+import (
+	"sync"
+)
+
+// Sequence represents an ordering constraint on a set of expectations, which
+// may be for different methods and different mock objects. Expectations are
+// added to a sequence with Expectation.InSequence, and must then be satisfied
+// in the order in which they were added. For example:
+//
+//     seq := oglemock.NewSequence()
+//     controller.ExpectCall(conn, "Open", "foo.go", 17)().InSequence(seq)
+//     controller.ExpectCall(conn, "Write", "foo.go", 18)(Any()).InSequence(seq)
+//     controller.ExpectCall(conn, "Close", "foo.go", 19)().InSequence(seq)
+//
+// A call that matches an expectation in a sequence before all of the
+// expectations preceding it in that sequence have been satisfied is reported
+// as an out-of-order call. So is a call that matches an expectation after one
+// following it in the sequence has been matched; above, a call to Open after
+// Close is out of order even if Open may be called any number of times.
+type Sequence struct {
+	mutex sync.Mutex
+
+	// The expectations added to the sequence, in order, less any that the
+	// controller has since discarded (see Controller.Checkpoint).
+	expectations []*InternalExpectation // Protected by mutex
+}
+
+// NewSequence creates an empty sequence, to which expectations may be added
+// with Expectation.InSequence.
+func NewSequence() *Sequence {
+	return &Sequence{}
+}
+
+// Add the supplied expectation to the end of the sequence, returning the
+// expectation that previously ended it (or nil if the sequence was empty).
+func (s *Sequence) addExpectation(e *InternalExpectation) *InternalExpectation {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var prev *InternalExpectation
+	if len(s.expectations) > 0 {
+		prev = s.expectations[len(s.expectations)-1]
+	}
+
+	if prev != e {
+		s.expectations = append(s.expectations, e)
+	}
+
+	return prev
+}
+
+// Remove the supplied expectation, which the controller has discarded, from
+// the sequence, so that expectations added later don't follow it.
+func (s *Sequence) removeExpectation(e *InternalExpectation) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var kept []*InternalExpectation
+	for _, other := range s.expectations {
+		if other != e {
+			kept = append(kept, other)
+		}
+	}
+
+	s.expectations = kept
+}
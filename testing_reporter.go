@@ -0,0 +1,97 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock
+
+import (
+	"fmt"
+	"path"
+	"testing"
+)
+
+// NewControllerForTest sets up a fresh controller that reports errors to the
+// supplied test using the standard testing package, and that automatically
+// calls Finish when the test and its subtests complete. For example:
+//
+//     func TestFoo(t *testing.T) {
+//         c := oglemock.NewControllerForTest(t)
+//         reader := mock_io.NewMockReader(c, "reader")
+//         [...]
+//     }
+//
+// Fatal errors are reported with t.Fatalf, which must be called from the
+// goroutine running the test. Code under test that calls mock methods from
+// other goroutines should avoid setting up expectations that may fail fatally.
+func NewControllerForTest(t testing.TB) Controller {
+	c := NewController(NewTestingReporter(t))
+	t.Cleanup(c.Finish)
+
+	return c
+}
+
+// NewTestingReporter returns an ErrorReporter that reports errors to the
+// supplied test using t.Errorf and t.Fatalf, and warnings (see
+// WarningReporter) using t.Logf.
+//
+// The reporter implements HelperReporter, so the testing package attributes
+// each report to the test code that called the mock method or controller. The
+// location of the offending call or expectation, which may be elsewhere (e.g.
+// for an unsatisfied expectation), is given on the first line of the message.
+func NewTestingReporter(t testing.TB) ErrorReporter {
+	return &testingReporter{t}
+}
+
+type testingReporter struct {
+	t testing.TB
+}
+
+// Format a report, starting with the supplied location. The message starts
+// on its own line because the testing package prefixes it with a location of
+// its own.
+func formatReport(fileName string, lineNumber int, err error) string {
+	if fileName == "" {
+		return fmt.Sprintf("\n%v", err)
+	}
+
+	return fmt.Sprintf("\n%s:%d:\n%v", path.Base(fileName), lineNumber, err)
+}
+
+func (r *testingReporter) HelperFunc() func() {
+	return r.t.Helper
+}
+
+func (r *testingReporter) ReportError(
+	fileName string,
+	lineNumber int,
+	err error) {
+	r.t.Helper()
+	r.t.Errorf("%s", formatReport(fileName, lineNumber, err))
+}
+
+func (r *testingReporter) ReportFatalError(
+	fileName string,
+	lineNumber int,
+	err error) {
+	r.t.Helper()
+	r.t.Fatalf("%s", formatReport(fileName, lineNumber, err))
+}
+
+func (r *testingReporter) ReportWarning(
+	fileName string,
+	lineNumber int,
+	err error) {
+	r.t.Helper()
+	r.t.Logf("%s", formatReport(fileName, lineNumber, err))
+}
@@ -0,0 +1,160 @@
+// Copyright 2015 Aaron Jacobs. All Rights Reserved.
+// Author: aaronjjacobs@gmail.com (Aaron Jacobs)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oglemock_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/jacobsa/oglematchers"
+	"github.com/jacobsa/oglemock"
+	. "github.com/jacobsa/ogletest"
+)
+
+func TestTestingReporter(t *testing.T) { RunTests(t) }
+
+////////////////////////////////////////////////////////////
+// Helpers
+////////////////////////////////////////////////////////////
+
+// A testing.TB that records what is reported to it. Methods not overridden
+// here panic, since the embedded interface is nil.
+type fakeTB struct {
+	testing.TB
+
+	errors   []string
+	fatals   []string
+	logs     []string
+	cleanups []func()
+	helpers  int
+}
+
+func (tb *fakeTB) Helper() {
+	tb.helpers++
+}
+
+func (tb *fakeTB) Errorf(format string, args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Fatalf(format string, args ...interface{}) {
+	tb.fatals = append(tb.fatals, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Logf(format string, args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Cleanup(f func()) {
+	tb.cleanups = append(tb.cleanups, f)
+}
+
+////////////////////////////////////////////////////////////
+// Boilerplate
+////////////////////////////////////////////////////////////
+
+type TestingReporterTest struct {
+	tb fakeTB
+}
+
+func init() { RegisterTestSuite(&TestingReporterTest{}) }
+
+////////////////////////////////////////////////////////////
+// Test functions
+////////////////////////////////////////////////////////////
+
+func (t *TestingReporterTest) ReportError() {
+	r := oglemock.NewTestingReporter(&t.tb)
+	r.ReportError("/foo/bar/taco.go", 17, errors.New("burrito"))
+
+	AssertEq(1, len(t.tb.errors))
+	ExpectEq(0, len(t.tb.fatals))
+	ExpectEq("\ntaco.go:17:\nburrito", t.tb.errors[0])
+}
+
+func (t *TestingReporterTest) ReportFatalError() {
+	r := oglemock.NewTestingReporter(&t.tb)
+	r.ReportFatalError("/foo/bar/taco.go", 17, errors.New("burrito"))
+
+	ExpectEq(0, len(t.tb.errors))
+	AssertEq(1, len(t.tb.fatals))
+	ExpectEq("\ntaco.go:17:\nburrito", t.tb.fatals[0])
+}
+
+func (t *TestingReporterTest) ReportWarning() {
+	r := oglemock.NewTestingReporter(&t.tb)
+	r.(oglemock.WarningReporter).ReportWarning("", 0, errors.New("burrito"))
+
+	ExpectEq(0, len(t.tb.errors))
+	AssertEq(1, len(t.tb.logs))
+	ExpectEq("\nburrito", t.tb.logs[0])
+}
+
+func (t *TestingReporterTest) FinishCalledOnCleanup() {
+	c := oglemock.NewControllerForTest(&t.tb)
+	o := &trivialMockObject{17, "taco"}
+
+	c.ExpectCall(o, "StringToInt", "burrito.go", 117)("")
+
+	// Nothing should happen until cleanup.
+	ExpectEq(0, len(t.tb.errors))
+	AssertEq(1, len(t.tb.cleanups))
+
+	t.tb.cleanups[0]()
+
+	AssertEq(1, len(t.tb.errors))
+	ExpectThat(t.tb.errors[0], HasSubstr("burrito.go:117"))
+	ExpectThat(t.tb.errors[0], HasSubstr("Unsatisfied"))
+}
+
+func (t *TestingReporterTest) FinishCalledAgainOnCleanup() {
+	c := oglemock.NewControllerForTest(&t.tb)
+	o := &trivialMockObject{17, "taco"}
+
+	c.ExpectCall(o, "StringToInt", "burrito.go", 117)("")
+
+	// As if the test had deferred a call to Finish.
+	c.Finish()
+	AssertEq(1, len(t.tb.errors))
+
+	// The cleanup shouldn't report the expectation again.
+	AssertEq(1, len(t.tb.cleanups))
+	t.tb.cleanups[0]()
+
+	ExpectEq(1, len(t.tb.errors))
+}
+
+func (t *TestingReporterTest) HelperFunc() {
+	c := oglemock.NewControllerForTest(&t.tb)
+
+	oglemock.HelperFunc(c)()
+	ExpectEq(1, t.tb.helpers)
+}
+
+func (t *TestingReporterTest) ControllerMarksItselfAsHelper() {
+	c := oglemock.NewControllerForTest(&t.tb)
+	o := &trivialMockObject{17, "taco"}
+
+	c.HandleMethodCall(o, "StringToInt", "burrito.go", 117, []interface{}{""})
+
+	AssertEq(1, len(t.tb.errors))
+	ExpectThat(t.tb.errors[0], HasSubstr("burrito.go:117"))
+
+	// HandleMethodCall, the code it calls, and the reporter should each have
+	// been marked.
+	ExpectGe(t.tb.helpers, 3)
+}